@@ -0,0 +1,169 @@
+package timekit
+
+import (
+	"sync"
+	"time"
+)
+
+// CatchUpPolicy controls what the `AlignedTicker` does with the boundaries it
+// missed, for example when the process was suspended or the receiver of the
+// ticks was too slow. A boundary is missed when the following boundary has
+// already passed by the time the ticker wakes up.
+type CatchUpPolicy int
+
+const (
+	// SkipMissedTicks drops every missed tick and waits for the next
+	// boundary.
+	SkipMissedTicks CatchUpPolicy = iota
+
+	// FireLastMissedTick delivers a single tick for the most recent missed
+	// boundary, which is what `time.Ticker` does.
+	FireLastMissedTick
+
+	// FireAllMissedTicks delivers a tick for every missed boundary in order.
+	FireAllMissedTicks
+)
+
+// AlignedTicker delivers ticks exactly on the wall clock boundaries of an
+// interval, for example every 5 minutes on :00, :05 and :10 or every day at
+// midnight, unlike `time.Ticker` which drifts from the time it was created.
+// Each tick is the boundary it was scheduled for (and not the time it was
+// delivered at) in the location of the ticker. Boundaries are counted on the
+// local wall clock, so an hourly ticker fires twice when the clocks go back
+// and a boundary which is skipped when the clocks go forward fires when the
+// gap ends.
+type AlignedTicker struct {
+	// C is the channel the ticks are delivered on.
+	C <-chan time.Time
+
+	c        chan time.Time
+	interval time.Duration
+	loc      *time.Location
+	policy   CatchUpPolicy
+	clock    Clock
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// AlignedTickerOption is a function which changes the settings of the
+// `AlignedTicker` when it is created.
+type AlignedTickerOption func(*AlignedTicker)
+
+// WithTickerLocation returns an option which sets the location whose wall
+// clock the boundaries are aligned to, by default the local time is used.
+func WithTickerLocation(loc *time.Location) AlignedTickerOption {
+	return func(at *AlignedTicker) {
+		at.loc = loc
+	}
+}
+
+// WithCatchUpPolicy returns an option which sets what happens to the missed
+// boundaries, by default they are skipped.
+func WithCatchUpPolicy(policy CatchUpPolicy) AlignedTickerOption {
+	return func(at *AlignedTicker) {
+		at.policy = policy
+	}
+}
+
+// WithTickerClock returns an option which sets the clock the ticker waits on,
+// for example a `FakeClock` in tests. By default the `RealClock` is used.
+func WithTickerClock(clock Clock) AlignedTickerOption {
+	return func(at *AlignedTicker) {
+		at.clock = clock
+	}
+}
+
+// NewAlignedTicker is a constructor of the `AlignedTicker` struct which ticks
+// on every boundary of the interval after now. Like `time.NewTicker` this
+// panics if the interval is not greater than zero. Make sure to call `Stop`
+// to release the ticker once it is no longer needed.
+func NewAlignedTicker(interval time.Duration, opts ...AlignedTickerOption) *AlignedTicker {
+	if interval <= 0 {
+		panic("timekit: non-positive interval for NewAlignedTicker")
+	}
+	c := make(chan time.Time, 1)
+	at := &AlignedTicker{
+		C:        c,
+		c:        c,
+		interval: interval,
+		loc:      time.Local,
+		policy:   SkipMissedTicks,
+		clock:    RealClock,
+		stop:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(at)
+	}
+	go at.run(at.clock.Now())
+	return at
+}
+
+// Stop turns off the ticker, no more ticks will be delivered. It is safe to
+// call more than once.
+func (at *AlignedTicker) Stop() {
+	at.stopOnce.Do(func() {
+		close(at.stop)
+	})
+}
+
+// run waits for every boundary after `now` and delivers the ticks until the
+// ticker is stopped.
+func (at *AlignedTicker) run(now time.Time) {
+	next := nextAlignedBoundary(now, at.interval, at.loc)
+	for {
+		timer := at.clock.NewTimer(next.Sub(at.clock.Now()))
+		select {
+		case <-timer.C():
+		case <-at.stop:
+			timer.Stop()
+			return
+		}
+
+		now = at.clock.Now()
+		if now.Before(next) {
+			// Woken up early (ex: the clock was set backwards) so wait again.
+			continue
+		}
+
+		following := nextAlignedBoundary(next, at.interval, at.loc)
+		switch {
+		case now.Before(following):
+			if !at.send(next) {
+				return
+			}
+		case at.policy == FireAllMissedTicks:
+			for ; !now.Before(next); next = nextAlignedBoundary(next, at.interval, at.loc) {
+				if !at.send(next) {
+					return
+				}
+			}
+			continue
+		case at.policy == FireLastMissedTick:
+			last := next
+			for dt := following; !now.Before(dt); dt = nextAlignedBoundary(dt, at.interval, at.loc) {
+				last = dt
+			}
+			if !at.send(last) {
+				return
+			}
+		}
+		next = nextAlignedBoundary(now, at.interval, at.loc)
+	}
+}
+
+// send delivers the tick and returns false if the ticker was stopped while
+// waiting for the receiver.
+func (at *AlignedTicker) send(dt time.Time) bool {
+	select {
+	case at.c <- dt.In(at.loc):
+		return true
+	case <-at.stop:
+		return false
+	}
+}
+
+// nextAlignedBoundary returns the first instant strictly after `after` whose
+// wall clock in the location falls on a boundary of the interval.
+func nextAlignedBoundary(after time.Time, interval time.Duration, loc *time.Location) time.Time {
+	return Ceil(after.Add(time.Nanosecond), interval, WithRoundingLocation(loc))
+}
@@ -0,0 +1,128 @@
+package timekit
+
+import (
+	"testing"
+	"time"
+)
+
+// receiveTick returns the next tick of the aligned ticker or fails the test
+// if none is delivered.
+func receiveTick(t *testing.T, at *AlignedTicker) time.Time {
+	t.Helper()
+	select {
+	case dt := <-at.C:
+		return dt
+	case <-time.After(5 * time.Second):
+		t.Fatal("Incorrect ticker, was expecting a tick")
+	}
+	return time.Time{}
+}
+
+func TestAlignedTicker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 3, 27, 0, time.UTC)
+	clock := NewFakeClock(start)
+	at := NewAlignedTicker(5*time.Minute, WithTickerClock(clock), WithTickerLocation(time.UTC))
+	defer at.Stop()
+
+	expected := []time.Time{
+		time.Date(2024, 1, 1, 9, 5, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 9, 10, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 9, 15, 0, 0, time.UTC),
+	}
+	for _, e := range expected {
+		clock.BlockUntil(1)
+		clock.Set(e)
+		if dt := receiveTick(t, at); !dt.Equal(e) {
+			t.Errorf("Incorrect tick, got %s but was expecting %s", dt, e)
+		}
+	}
+}
+
+func TestAlignedTickerCatchUpPolicy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	resume := time.Date(2024, 1, 1, 9, 47, 0, 0, time.UTC) // Suspended from 9:00 AM until 9:47 AM.
+
+	////
+	//// Case 1: Skip the missed ticks.
+	////
+
+	clock := NewFakeClock(start)
+	at := NewAlignedTicker(15*time.Minute, WithTickerClock(clock), WithTickerLocation(time.UTC))
+	clock.BlockUntil(1)
+	clock.Set(resume)
+	clock.BlockUntil(1)
+	clock.Set(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	if dt := receiveTick(t, at); !dt.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect tick after skipping, got %s", dt)
+	}
+	at.Stop()
+
+	////
+	//// Case 2: Fire the last missed tick.
+	////
+
+	clock = NewFakeClock(start)
+	at = NewAlignedTicker(15*time.Minute, WithTickerClock(clock), WithTickerLocation(time.UTC), WithCatchUpPolicy(FireLastMissedTick))
+	clock.BlockUntil(1)
+	clock.Set(resume)
+	if dt := receiveTick(t, at); !dt.Equal(time.Date(2024, 1, 1, 9, 45, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect last missed tick, got %s", dt)
+	}
+	at.Stop()
+
+	////
+	//// Case 3: Fire every missed tick.
+	////
+
+	clock = NewFakeClock(start)
+	at = NewAlignedTicker(15*time.Minute, WithTickerClock(clock), WithTickerLocation(time.UTC), WithCatchUpPolicy(FireAllMissedTicks))
+	clock.BlockUntil(1)
+	clock.Set(resume)
+	for _, e := range []time.Time{
+		time.Date(2024, 1, 1, 9, 15, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 9, 45, 0, 0, time.UTC),
+	} {
+		if dt := receiveTick(t, at); !dt.Equal(e) {
+			t.Errorf("Incorrect missed tick, got %s but was expecting %s", dt, e)
+		}
+	}
+	at.Stop()
+}
+
+func TestNextAlignedBoundaryDaylightSavingTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	////
+	//// Case 1: Daily boundaries stay on local midnight.
+	////
+
+	actual := nextAlignedBoundary(time.Date(2024, 3, 10, 12, 0, 0, 0, loc), 24*time.Hour, loc)
+	if expected := time.Date(2024, 3, 11, 0, 0, 0, 0, loc); !actual.Equal(expected) {
+		t.Errorf("Incorrect daily boundary, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 2: Hourly boundaries skip the missing 2 AM and repeat 1 AM.
+	////
+
+	actual = nextAlignedBoundary(time.Date(2024, 3, 10, 1, 30, 0, 0, loc), time.Hour, loc)
+	if expected := time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect spring forward boundary, got %s but was expecting %s", actual, expected)
+	}
+	first := nextAlignedBoundary(time.Date(2024, 11, 3, 4, 30, 0, 0, time.UTC), time.Hour, loc) // 12:30 AM EDT
+	second := nextAlignedBoundary(first, time.Hour, loc)
+	third := nextAlignedBoundary(second, time.Hour, loc)
+	for i, e := range []time.Time{
+		time.Date(2024, 11, 3, 5, 0, 0, 0, time.UTC), // 1 AM EDT
+		time.Date(2024, 11, 3, 6, 0, 0, 0, time.UTC), // 1 AM EST
+		time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC), // 2 AM EST
+	} {
+		if actual := []time.Time{first, second, third}[i]; !actual.Equal(e) {
+			t.Errorf("Incorrect fall back boundary %d, got %s but was expecting %s", i, actual, e)
+		}
+	}
+}
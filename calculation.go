@@ -1,6 +1,7 @@
 package timekit
 
 import (
+	"iter"
 	"time"
 )
 
@@ -219,24 +220,66 @@ func AddWeeksToTime(dt time.Time, weeks int) time.Time {
 	return dt.AddDate(0, 0, 7*weeks)
 }
 
-// GetDatesForWeekdaysBetweenRange returns all the date-times between two dates that fall for the specific picked weekdays.
-func GetDatesForWeekdaysBetweenRange(start time.Time, end time.Time, weekdays []int8) []time.Time {
-	times := []time.Time{}
+// AddMonthsClamped returns new time with months added to it where the day is
+// clamped to the last day of the resulting month, for example one month after
+// Jan 31st is Feb 28th (or Feb 29th in leap years) instead of Mar 3rd like
+// `AddDate` returns. The time of day is kept.
+func AddMonthsClamped(dt time.Time, months int) time.Time {
+	return AddMonthsAnchored(dt, months, dt.Day())
+}
 
-	// Create all the dates, incremented by day, from the start to finish datetimes.
-	dts := RangeFromTimeStepper(start, end, 0, 0, 1, 0, 0, 0)
+// AddYearsClamped returns new time with years added to it where Feb 29th is
+// clamped to Feb 28th in the years which are not leap years.
+func AddYearsClamped(dt time.Time, years int) time.Time {
+	return AddMonthsClamped(dt, years*12)
+}
 
-	// Iterate through all the datetimes and check to see if the weekdays match.
-	for _, dt := range dts {
-		wkInt := int8(dt.Weekday())
+// AddMonthsAnchored returns new time with months added to it on the anchor
+// day, or the last day of the resulting month if it is shorter. Unlike
+// `AddMonthsClamped` the day does not shrink when adding repeatedly, for
+// example adding one month at a time with an anchor day of 31 goes from Jan
+// 31st to Feb 29th, Mar 31st, Apr 30th and May 31st.
+func AddMonthsAnchored(dt time.Time, months int, anchorDay int) time.Time {
+	// Developers Note:
+	// We use the first day of the month to add the months so we never
+	// overflow into the following month.
+	monthDT := time.Date(dt.Year(), dt.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	day := min(max(anchorDay, 1), daysIn(monthDT.Year(), monthDT.Month()))
+	return time.Date(monthDT.Year(), monthDT.Month(), day, dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), dt.Location())
+}
 
-		// Iterate through the specificed weekdays.
-		for _, weekday := range weekdays {
-			if wkInt == weekday {
-				times = append(times, dt)
+// GetDatesForWeekdaysBetweenRange returns all the date-times between two dates that fall for the specific picked weekdays.
+func GetDatesForWeekdaysBetweenRange(start time.Time, end time.Time, weekdays []int8) []time.Time {
+	return collectTimes(WeekdaysBetweenRange(start, end, weekdays))
+}
+
+// WeekdaysBetweenRange returns an iterator which lazily yields the same date-times as the `GetDatesForWeekdaysBetweenRange` function.
+func WeekdaysBetweenRange(start time.Time, end time.Time, weekdays []int8) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		// Iterate through all the dates, incremented by day, from the start to
+		// finish datetimes and check to see if the weekdays match.
+		for dt := range StepsFromTimeStepper(start, end, 0, 0, 1, 0, 0, 0) {
+			wkInt := int8(dt.Weekday())
+
+			// Iterate through the specificed weekdays.
+			for _, weekday := range weekdays {
+				if wkInt == weekday {
+					if !yield(dt) {
+						return
+					}
+				}
 			}
 		}
 	}
+}
+
+// collectTimes returns all the date-times yielded by the iterator. Unlike
+// `slices.Collect` an empty iterator returns an empty (non-nil) slice.
+func collectTimes(seq iter.Seq[time.Time]) []time.Time {
+	times := []time.Time{}
+	for dt := range seq {
+		times = append(times, dt)
+	}
 	return times
 }
 
@@ -305,145 +348,384 @@ func IsTimeOnLastWeekOfMonth(pickedDT time.Time) bool {
 
 // GetDatesByWeeklyBasedRecurringSchedule Generates a list of datetimes based on a weekly recuring schedule. Please note that dates start in first week and then week frequency is applied to restrict some weeks.
 func GetDatesByWeeklyBasedRecurringSchedule(startDT time.Time, weekdays []int8, totalWeeks int, weekFrequency int) []time.Time {
-	// Variable will store the datetimes that belong in the weekly based recurring schedule.
-	scheduleTimes := []time.Time{}
+	return collectTimes(WeeklyBasedRecurringSchedule(startDT, weekdays, totalWeeks, weekFrequency))
+}
 
-	// Variable will calculate the last date based on total weeks in schedule.
-	endDT := AddWeeksToTime(startDT, totalWeeks-1)
+// WeeklyBasedRecurringSchedule returns an iterator which lazily yields the same datetimes as the `GetDatesByWeeklyBasedRecurringSchedule` function.
+func WeeklyBasedRecurringSchedule(startDT time.Time, weekdays []int8, totalWeeks int, weekFrequency int) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		// Developers Note:
+		// Without a week frequency we would divide by zero, use the `Generate`
+		// function if you need an error for invalid schedules.
+		if weekFrequency < 1 {
+			return
+		}
 
-	// Create all the dates, incremented by day, from the start to finish datetimes.
-	dts := RangeFromTimeStepper(startDT, endDT, 0, 0, 1, 0, 0, 0)
+		// Variable will calculate the last date based on total weeks in schedule.
+		endDT := AddWeeksToTime(startDT, totalWeeks-1)
 
-	// Variable is used to track when to trigger creation of the scheduled time.
-	var weekFrequencyIterator int = 0
+		// Variable is used to track when to trigger creation of the scheduled time.
+		var weekFrequencyIterator int = 0
 
-	// Variable used to track how many days have elapsed.
-	var dayIterator int = 0
+		// Variable used to track how many days have elapsed.
+		var dayIterator int = 0
 
-	// Iterate through all the days between the start to end date.
-	for _, todayDT := range dts {
-		// Get weekday integer from the current iteration date.
-		weekdayInt := int8(todayDT.Weekday())
+		// Iterate through all the days, incremented by day, between the start to end date.
+		for todayDT := range StepsFromTimeStepper(startDT, endDT, 0, 0, 1, 0, 0, 0) {
+			// Get weekday integer from the current iteration date.
+			weekdayInt := int8(todayDT.Weekday())
 
-		// If seven days elapsed then the end of the week occured.
-		if dayIterator%7 == 0 && dayIterator != 0 {
-			weekFrequencyIterator++
-		}
+			// If seven days elapsed then the end of the week occured.
+			if dayIterator%7 == 0 && dayIterator != 0 {
+				weekFrequencyIterator++
+			}
 
-		// Iterate through the picked weekdays that the user has chosen and see
-		// if today's date
-		for _, weekday := range weekdays {
-			if weekdayInt == weekday {
-				if weekFrequencyIterator%weekFrequency == 0 {
-					scheduleTimes = append(scheduleTimes, todayDT)
+			// Iterate through the picked weekdays that the user has chosen and see
+			// if today's date
+			for _, weekday := range weekdays {
+				if weekdayInt == weekday {
+					if weekFrequencyIterator%weekFrequency == 0 {
+						if !yield(todayDT) {
+							return
+						}
+					}
 				}
 			}
-		}
 
-		// We finished processing this day so keep track in our day iterator and continue.
-		dayIterator++
+			// We finished processing this day so keep track in our day iterator and continue.
+			dayIterator++
+		}
 	}
-	return scheduleTimes
 }
 
-// GetDatesForExactDayByMonthlyBasedRecurringSchedule Generates a list of datetimes based on a monthly recuring schedule for the specific day number.
-func GetDatesForExactDayByMonthlyBasedRecurringSchedule(startDT time.Time, totalMonths int, onExactDay int) []time.Time {
-	// Variable will store the datetimes that belong in the monthly based recurring schedule.
-	scheduleTimes := []time.Time{}
+// MissingDayPolicy controls what the exact day monthly recurring schedule
+// does in months which do not have the requested day, for example most months
+// do not have a 31st.
+type MissingDayPolicy int
 
-	// Variable will calculate the last date based on total weeks in schedule.
-	endDT := startDT.AddDate(0, totalMonths-1, 0)
+const (
+	// SkipMissingDay skips the months without the requested day.
+	SkipMissingDay MissingDayPolicy = iota
 
-	// Create all the dates, incremented by day, from the start to finish datetimes.
-	dts := RangeFromTimeStepper(startDT, endDT, 0, 0, 1, 0, 0, 0)
+	// UseLastDayForMissing uses the last day of the months without the
+	// requested day, for example Feb 28th (or Feb 29th) instead of Feb 31st.
+	UseLastDayForMissing
+)
 
-	// Iterate through all the days between the start to end date.
-	for _, todayDT := range dts {
-		if onExactDay == todayDT.Day() {
-			scheduleTimes = append(scheduleTimes, todayDT)
+// GetDatesForExactDayByMonthlyBasedRecurringSchedule Generates a list of datetimes based on a monthly recuring schedule for the specific day number. The dates are searched from the starting date up to `totalMonths-1` months after it and months without the day (ex: the 31st) are skipped, use `GetDatesForExactDayByMonthlyBasedRecurringScheduleWithPolicy` to use the last day of those months instead.
+func GetDatesForExactDayByMonthlyBasedRecurringSchedule(startDT time.Time, totalMonths int, onExactDay int) []time.Time {
+	return collectTimes(ExactDayByMonthlyBasedRecurringSchedule(startDT, totalMonths, onExactDay))
+}
+
+// ExactDayByMonthlyBasedRecurringSchedule returns an iterator which lazily yields the same datetimes as the `GetDatesForExactDayByMonthlyBasedRecurringSchedule` function.
+func ExactDayByMonthlyBasedRecurringSchedule(startDT time.Time, totalMonths int, onExactDay int) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		// Variable will calculate the last date based on total weeks in schedule.
+		endDT := startDT.AddDate(0, totalMonths-1, 0)
+
+		// Iterate through all the days, incremented by day, between the start to end date.
+		for todayDT := range StepsFromTimeStepper(startDT, endDT, 0, 0, 1, 0, 0, 0) {
+			if onExactDay == todayDT.Day() {
+				if !yield(todayDT) {
+					return
+				}
+			}
 		}
 	}
+}
 
-	return scheduleTimes
+// GetDatesForExactDayByMonthlyBasedRecurringScheduleWithPolicy Generates a list of datetimes based on a monthly recuring schedule for the specific day number in each of the `totalMonths` months beginning with the month of the starting date. The `policy` controls what happens in months without the day (ex: the 31st), for example `UseLastDayForMissing` is useful for subscription renewals. Unlike `GetDatesForExactDayByMonthlyBasedRecurringSchedule` the last of the `totalMonths` months is searched in full. Dates keep the time of day of the starting date and dates before the starting date are not included.
+func GetDatesForExactDayByMonthlyBasedRecurringScheduleWithPolicy(startDT time.Time, totalMonths int, onExactDay int, policy MissingDayPolicy) []time.Time {
+	return collectTimes(ExactDayByMonthlyBasedRecurringScheduleWithPolicy(startDT, totalMonths, onExactDay, policy))
+}
+
+// ExactDayByMonthlyBasedRecurringScheduleWithPolicy returns an iterator which lazily yields the same datetimes as the `GetDatesForExactDayByMonthlyBasedRecurringScheduleWithPolicy` function.
+func ExactDayByMonthlyBasedRecurringScheduleWithPolicy(startDT time.Time, totalMonths int, onExactDay int, policy MissingDayPolicy) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if onExactDay < 1 || onExactDay > 31 {
+			return
+		}
+
+		for i := 0; i < totalMonths; i++ {
+			dt := AddMonthsAnchored(startDT, i, onExactDay)
+			if dt.Day() != onExactDay && policy != UseLastDayForMissing {
+				continue
+			}
+			if dt.Before(startDT) {
+				continue
+			}
+			if !yield(dt) {
+				return
+			}
+		}
+	}
 }
 
 // GetDatesForFirstWeekDayByMonthlyBasedRecurringSchedule Generates a list of datetimes based on a monthly recuring schedule which will find all the dates that fall on the weekday of the first week.
 func GetDatesForFirstWeekDayByMonthlyBasedRecurringSchedule(startDT time.Time, totalMonths int, onFirstWeekday int) []time.Time {
-	// Variable will store the datetimes that belong in the monthly based recurring schedule.
-	scheduleTimes := []time.Time{}
-
-	// Variable will calculate the last date based on total weeks in schedule.
-	endDT := startDT.AddDate(0, totalMonths-1, 7) // Why "7"? Just to take into account extra week so we can handle for that particular months first week.
+	return collectTimes(FirstWeekDayByMonthlyBasedRecurringSchedule(startDT, totalMonths, onFirstWeekday))
+}
 
-	// Create all the dates, incremented by day, from the start to finish datetimes.
-	dts := RangeFromTimeStepper(startDT, endDT, 0, 0, 1, 0, 0, 0)
+// FirstWeekDayByMonthlyBasedRecurringSchedule returns an iterator which lazily yields the same datetimes as the `GetDatesForFirstWeekDayByMonthlyBasedRecurringSchedule` function.
+func FirstWeekDayByMonthlyBasedRecurringSchedule(startDT time.Time, totalMonths int, onFirstWeekday int) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		// Variable will calculate the last date based on total weeks in schedule.
+		endDT := startDT.AddDate(0, totalMonths-1, 7) // Why "7"? Just to take into account extra week so we can handle for that particular months first week.
 
-	monthIterator := startDT.Month()
+		// Developers Note:
+		// We track the month as a count of months (and not as a `time.Month`)
+		// so that incrementing past December wraps around to January.
+		monthIterator := monthIndex(startDT)
 
-	// Iterate through all the days between the start to end date.
-	for _, todayDT := range dts {
+		// Iterate through all the days, incremented by day, between the start to end date.
+		for todayDT := range StepsFromTimeStepper(startDT, endDT, 0, 0, 1, 0, 0, 0) {
 
-		// Get weekday integer from the current iteration date.
-		todayWeekdayInt := int(todayDT.Weekday())
+			// Get weekday integer from the current iteration date.
+			todayWeekdayInt := int(todayDT.Weekday())
 
-		// If the picked day match the current day.
-		if todayWeekdayInt == onFirstWeekday {
+			// If the picked day match the current day.
+			if todayWeekdayInt == onFirstWeekday {
 
-			// And if we are looking at the correct monthly then save.
-			if monthIterator == todayDT.Month() {
-				scheduleTimes = append(scheduleTimes, todayDT)
+				// And if we are looking at the correct monthly then save.
+				if monthIterator == monthIndex(todayDT) {
+					if !yield(todayDT) {
+						return
+					}
 
-				// We already have the first occurance of the date for this
-				// month so we can increment the month iterator so we know we
-				// need to look for our next month.
-				monthIterator++
+					// We already have the first occurance of the date for this
+					// month so we can increment the month iterator so we know we
+					// need to look for our next month.
+					monthIterator++
+				}
 			}
 		}
 	}
-
-	return scheduleTimes
 }
 
 // GetDatesForLastWeekDayByMonthlyBasedRecurringSchedule Generates a list of datetimes based on a monthly recuring schedule which will find all the dates that fall on the weekday of the last week.
 func GetDatesForLastWeekDayByMonthlyBasedRecurringSchedule(startDT time.Time, totalMonths int, onLastWeekday int) []time.Time {
-	// Variable will store the datetimes that belong in the monthly based recurring schedule.
-	scheduleTimes := []time.Time{}
+	return collectTimes(LastWeekDayByMonthlyBasedRecurringSchedule(startDT, totalMonths, onLastWeekday))
+}
 
-	// Variable will calculate the last date based on total weeks in schedule.
-	endDT := startDT.AddDate(0, totalMonths, 0) // Why "7"? Just to take into account extra week so we can handle for that particular months first week.
+// LastWeekDayByMonthlyBasedRecurringSchedule returns an iterator which lazily yields the same datetimes as the `GetDatesForLastWeekDayByMonthlyBasedRecurringSchedule` function.
+func LastWeekDayByMonthlyBasedRecurringSchedule(startDT time.Time, totalMonths int, onLastWeekday int) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		// Variable will calculate the last date based on total weeks in schedule.
+		endDT := startDT.AddDate(0, totalMonths, 0)
 
-	// Create all the dates, incremented by day, from the start to finish datetimes.
-	dts := RangeFromTimeStepper(startDT, endDT, 0, 0, 1, 0, 0, 0)
+		// Developers Note:
+		// We track the month as a count of months (and not as a `time.Month`)
+		// so that incrementing past December wraps around to January.
+		monthIterator := monthIndex(startDT)
 
-	monthIterator := startDT.Month()
+		// Iterate through all the days, incremented by day, between the start to end date.
+		for todayDT := range StepsFromTimeStepper(startDT, endDT, 0, 0, 1, 0, 0, 0) {
 
-	// Iterate through all the days between the start to end date.
-	for _, todayDT := range dts {
+			// Check to see if the current date we are iterating through is part of
+			if IsTimeOnLastWeekOfMonth(todayDT) {
 
-		// Check to see if the current date we are iterating through is part of
-		if IsTimeOnLastWeekOfMonth(todayDT) {
+				// Get weekday integer from the current iteration date.
+				todayWeekdayInt := int(todayDT.Weekday())
 
-			// Get weekday integer from the current iteration date.
-			todayWeekdayInt := int(todayDT.Weekday())
+				// If the picked day match the current day.
+				if todayWeekdayInt == onLastWeekday {
 
-			// If the picked day match the current day.
-			if todayWeekdayInt == onLastWeekday {
+					// And if we are looking at the correct monthly then save.
+					if monthIterator == monthIndex(todayDT) {
+						if !yield(todayDT) {
+							return
+						}
 
-				// And if we are looking at the correct monthly then save.
-				if monthIterator == todayDT.Month() {
-					scheduleTimes = append(scheduleTimes, todayDT)
+						// We already have the first occurance of the date for this
+						// month so we can increment the month iterator so we know we
+						// need to look for our next month.
+						monthIterator++
+					}
+				}
+			}
+		}
+	}
+}
 
-					// We already have the first occurance of the date for this
-					// month so we can increment the month iterator so we know we
-					// need to look for our next month.
-					monthIterator++
+// MissingWeekdayPolicy controls what the nth weekday monthly recurring
+// schedule does in months which do not have the requested occurrence, for
+// example most months do not have a 5th Friday.
+type MissingWeekdayPolicy int
+
+const (
+	// SkipMissingWeekday skips the months without the requested occurrence.
+	SkipMissingWeekday MissingWeekdayPolicy = iota
+
+	// UseLastWeekdayForMissing uses the last occurrence of the weekday in the
+	// months without the requested occurrence, for example the 4th Friday
+	// instead of the 5th Friday. When counting from the end of the month
+	// (ex: the 5th last Friday) the first occurrence is used instead.
+	UseLastWeekdayForMissing
+)
+
+// GetDatesForNthWeekDayByMonthlyBasedRecurringSchedule Generates a list of datetimes based on a monthly recuring schedule which will find the date of the nth occurrence of the weekday in every month, for example the 2nd Tuesday (`nth` of 2 and `onWeekday` of 2) or the 3rd Friday (`nth` of 3 and `onWeekday` of 5). The `nth` value can be 1 to 5 or -1 to -5 to count from the end of the month, for example -1 is the last occurrence. The `policy` controls what happens in months without the occurrence (ex: the 5th Monday). Dates keep the time of day of the starting date and dates before the starting date are not included.
+func GetDatesForNthWeekDayByMonthlyBasedRecurringSchedule(startDT time.Time, totalMonths int, nth int, onWeekday int, policy MissingWeekdayPolicy) []time.Time {
+	return collectTimes(NthWeekDayByMonthlyBasedRecurringSchedule(startDT, totalMonths, nth, onWeekday, policy))
+}
+
+// NthWeekDayByMonthlyBasedRecurringSchedule returns an iterator which lazily yields the same datetimes as the `GetDatesForNthWeekDayByMonthlyBasedRecurringSchedule` function.
+func NthWeekDayByMonthlyBasedRecurringSchedule(startDT time.Time, totalMonths int, nth int, onWeekday int, policy MissingWeekdayPolicy) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if nth == 0 || nth < -5 || nth > 5 || onWeekday < 0 || onWeekday > 6 {
+			return
+		}
+
+		for i := 0; i < totalMonths; i++ {
+			// Developers Note:
+			// We use the first day of the month to step through the months so
+			// we never overflow into the following month (ex: Jan 31st + 1).
+			monthDT := time.Date(startDT.Year(), startDT.Month()+time.Month(i), 1, 0, 0, 0, 0, startDT.Location())
+
+			day, ok := nthWeekdayOfMonth(monthDT.Year(), monthDT.Month(), nth, time.Weekday(onWeekday))
+			if !ok {
+				if policy != UseLastWeekdayForMissing {
+					continue
+				}
+				day, _ = nthWeekdayOfMonth(monthDT.Year(), monthDT.Month(), -1, time.Weekday(onWeekday))
+				if nth < 0 {
+					day, _ = nthWeekdayOfMonth(monthDT.Year(), monthDT.Month(), 1, time.Weekday(onWeekday))
 				}
 			}
+
+			dt := time.Date(monthDT.Year(), monthDT.Month(), day, startDT.Hour(), startDT.Minute(), startDT.Second(), startDT.Nanosecond(), startDT.Location())
+			if dt.Before(startDT) {
+				continue
+			}
+			if !yield(dt) {
+				return
+			}
 		}
+	}
+}
 
+// nthWeekdayOfMonth returns the day of the month of the nth occurrence of the
+// weekday, negative values count backwards from the end of the month. The
+// boolean is false if the month does not have that occurrence.
+func nthWeekdayOfMonth(year int, month time.Month, nth int, weekday time.Weekday) (int, bool) {
+	lastDay := daysIn(year, month)
+	var day int
+	if nth > 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		day = 1 + (int(weekday)-int(first)+7)%7 + 7*(nth-1)
+	} else {
+		last := time.Date(year, month, lastDay, 0, 0, 0, 0, time.UTC).Weekday()
+		day = lastDay - (int(last)-int(weekday)+7)%7 + 7*(nth+1)
 	}
+	return day, day >= 1 && day <= lastDay
+}
+
+// LeapDayPolicy controls what the yearly recurring schedule does for a Feb 29th
+// date in the years which are not leap years.
+type LeapDayPolicy int
+
+const (
+	// SkipLeapDay skips the years which do not have a Feb 29th.
+	SkipLeapDay LeapDayPolicy = iota
+
+	// UseFeb28ForLeapDay uses Feb 28th in the years without a Feb 29th.
+	UseFeb28ForLeapDay
 
-	return scheduleTimes
+	// UseMar1ForLeapDay uses Mar 1st in the years without a Feb 29th.
+	UseMar1ForLeapDay
+)
+
+// GetDatesForExactDayByYearlyBasedRecurringSchedule Generates a list of datetimes based on a yearly recuring schedule for the specific month and day number, for example anniversaries or annual reviews. The schedule repeats every `yearFrequency` years beginning with the year of the starting date and stops after `totalCount` dates or after the `untilDT` date (inclusive), whichever comes first; use a `totalCount` of zero or a zero `untilDT` to only use the other condition. The `policy` controls what happens to Feb 29th in the years which are not leap years. Dates keep the time of day of the starting date and dates before the starting date are not included.
+func GetDatesForExactDayByYearlyBasedRecurringSchedule(startDT time.Time, onMonth int, onExactDay int, yearFrequency int, totalCount int, untilDT time.Time, policy LeapDayPolicy) []time.Time {
+	if totalCount <= 0 && untilDT.IsZero() {
+		return []time.Time{}
+	}
+	return collectTimes(ExactDayByYearlyBasedRecurringSchedule(startDT, onMonth, onExactDay, yearFrequency, totalCount, untilDT, policy))
+}
+
+// ExactDayByYearlyBasedRecurringSchedule returns an iterator which lazily yields the same datetimes as the `GetDatesForExactDayByYearlyBasedRecurringSchedule` function. Unlike the slice function, the iterator keeps going (until the year 9999) when neither a count nor an until date is set.
+func ExactDayByYearlyBasedRecurringSchedule(startDT time.Time, onMonth int, onExactDay int, yearFrequency int, totalCount int, untilDT time.Time, policy LeapDayPolicy) iter.Seq[time.Time] {
+	// Developers Note:
+	// We check against a leap year so Feb 29th is allowed since it is the
+	// only date which exists in some years but not in others.
+	if onMonth < 1 || onMonth > 12 || onExactDay < 1 || onExactDay > daysIn(2000, time.Month(onMonth)) {
+		return func(yield func(time.Time) bool) {}
+	}
+	return yearlyBasedRecurringSchedule(startDT, yearFrequency, totalCount, untilDT, func(year int) (time.Time, bool) {
+		if onExactDay > daysIn(year, time.Month(onMonth)) {
+			switch policy {
+			case UseFeb28ForLeapDay:
+				return time.Date(year, time.February, 28, startDT.Hour(), startDT.Minute(), startDT.Second(), startDT.Nanosecond(), startDT.Location()), true
+			case UseMar1ForLeapDay:
+				return time.Date(year, time.March, 1, startDT.Hour(), startDT.Minute(), startDT.Second(), startDT.Nanosecond(), startDT.Location()), true
+			default:
+				return time.Time{}, false
+			}
+		}
+		return time.Date(year, time.Month(onMonth), onExactDay, startDT.Hour(), startDT.Minute(), startDT.Second(), startDT.Nanosecond(), startDT.Location()), true
+	})
+}
+
+// GetDatesForNthWeekDayByYearlyBasedRecurringSchedule Generates a list of datetimes based on a yearly recuring schedule which will find the date of the nth occurrence of the weekday in the month, for example the last Friday of November (`onMonth` of 11, `nth` of -1 and `onWeekday` of 5). The `nth` value can be 1 to 5 or -1 to -5 to count from the end of the month. The `yearFrequency`, `totalCount` and `untilDT` parameters work the same as in the `GetDatesForExactDayByYearlyBasedRecurringSchedule` function and the `policy` controls what happens in years where the month does not have the occurrence.
+func GetDatesForNthWeekDayByYearlyBasedRecurringSchedule(startDT time.Time, onMonth int, nth int, onWeekday int, yearFrequency int, totalCount int, untilDT time.Time, policy MissingWeekdayPolicy) []time.Time {
+	if totalCount <= 0 && untilDT.IsZero() {
+		return []time.Time{}
+	}
+	return collectTimes(NthWeekDayByYearlyBasedRecurringSchedule(startDT, onMonth, nth, onWeekday, yearFrequency, totalCount, untilDT, policy))
+}
+
+// NthWeekDayByYearlyBasedRecurringSchedule returns an iterator which lazily yields the same datetimes as the `GetDatesForNthWeekDayByYearlyBasedRecurringSchedule` function.
+func NthWeekDayByYearlyBasedRecurringSchedule(startDT time.Time, onMonth int, nth int, onWeekday int, yearFrequency int, totalCount int, untilDT time.Time, policy MissingWeekdayPolicy) iter.Seq[time.Time] {
+	if onMonth < 1 || onMonth > 12 || nth == 0 || nth < -5 || nth > 5 || onWeekday < 0 || onWeekday > 6 {
+		return func(yield func(time.Time) bool) {}
+	}
+	return yearlyBasedRecurringSchedule(startDT, yearFrequency, totalCount, untilDT, func(year int) (time.Time, bool) {
+		month := time.Month(onMonth)
+		day, ok := nthWeekdayOfMonth(year, month, nth, time.Weekday(onWeekday))
+		if !ok {
+			if policy != UseLastWeekdayForMissing {
+				return time.Time{}, false
+			}
+			day, _ = nthWeekdayOfMonth(year, month, -1, time.Weekday(onWeekday))
+			if nth < 0 {
+				day, _ = nthWeekdayOfMonth(year, month, 1, time.Weekday(onWeekday))
+			}
+		}
+		return time.Date(year, month, day, startDT.Hour(), startDT.Minute(), startDT.Second(), startDT.Nanosecond(), startDT.Location()), true
+	})
+}
+
+// yearlyBasedRecurringSchedule returns an iterator which yields the date
+// picked by the `dateForYear` function for every `yearFrequency` years from
+// the starting date while enforcing the count and until date conditions.
+func yearlyBasedRecurringSchedule(startDT time.Time, yearFrequency int, totalCount int, untilDT time.Time, dateForYear func(year int) (time.Time, bool)) iter.Seq[time.Time] {
+	if yearFrequency < 1 {
+		yearFrequency = 1
+	}
+	return func(yield func(time.Time) bool) {
+		count := 0
+		for year := startDT.Year(); year <= rruleMaxYear; year += yearFrequency {
+			dt, ok := dateForYear(year)
+			if !ok || dt.Before(startDT) {
+				continue
+			}
+			if !untilDT.IsZero() && dt.After(untilDT) {
+				return
+			}
+			if !yield(dt) {
+				return
+			}
+			count++
+			if totalCount > 0 && count >= totalCount {
+				return
+			}
+		}
+	}
+}
+
+// monthIndex returns the number of months since year zero which is used to
+// compare or step through months across year boundaries.
+func monthIndex(dt time.Time) int {
+	return dt.Year()*12 + int(dt.Month()) - 1
 }
 
 // GetHourRange function will take a date value and return two date times:
@@ -463,13 +745,11 @@ func GetDatesForLastWeekDayByMonthlyBasedRecurringSchedule(startDT time.Time, to
 // Monday Dec 18th - 5:10 PM --> (1) Monday Dec 18th - 5:00 PM and (2) Monday Dec 18th - 7:00 PM
 // Monday Dec 18th - 10:55 PM --> (1) Monday Dec 18th - 10:00 PM and (2) Monday Dec 18th - 11:00 PM
 func GetHourRange(dt time.Time) (time.Time, time.Time) {
-	// Discard the minutes and seconds to get the starting hour
-	startHour := time.Date(dt.Year(), dt.Month(), dt.Day(), dt.Hour(), 0, 0, 0, dt.Location())
-
-	// Add 1 hour to get the ending hour while discarding the minutes
-	endHour := startHour.Add(time.Hour)
-
-	return startHour, endHour
+	// Developers Note:
+	// We reuse the `HourlyRangeForTime` function so both functions handle the
+	// daylight saving transitions the same way.
+	dtr := HourlyRangeForTime(dt)
+	return dtr.Start, dtr.End
 }
 
 // HourRangeForNow works just like the `GetHourRange` function however it works
@@ -658,6 +658,136 @@ func TestGetDatesForExactDayByMonthlyBasedRecurringSchedule(t *testing.T) {
 	////
 }
 
+func TestGetDatesForExactDayByMonthlyBasedRecurringScheduleWindow(t *testing.T) {
+	startDateTime := time.Date(2024, 1, 5, 9, 30, 0, 0, time.UTC)
+
+	////
+	//// Case 1: The search stops `totalMonths-1` months after the starting date.
+	////
+
+	actual := GetDatesForExactDayByMonthlyBasedRecurringSchedule(startDateTime, 3, 10)
+	expected := []time.Time{
+		time.Date(2024, 1, 10, 9, 30, 0, 0, time.UTC),
+		time.Date(2024, 2, 10, 9, 30, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 2: A single month only searches the starting date.
+	////
+
+	actual = GetDatesForExactDayByMonthlyBasedRecurringSchedule(startDateTime, 1, 10)
+	if len(actual) != 0 {
+		t.Errorf("Incorrect date, got %s but was expecting none", actual)
+	}
+
+	////
+	//// Case 3: The policy variant searches every month in full.
+	////
+
+	actual = GetDatesForExactDayByMonthlyBasedRecurringScheduleWithPolicy(startDateTime, 3, 10, SkipMissingDay)
+	expected = append(expected, time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC))
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestGetDatesForExactDayByMonthlyBasedRecurringScheduleWithPolicy(t *testing.T) {
+
+	////
+	//// Case 1: Skip the months without the 31st.
+	////
+
+	startDateTime := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+	actual := GetDatesForExactDayByMonthlyBasedRecurringScheduleWithPolicy(startDateTime, 4, 31, SkipMissingDay)
+	expected := []time.Time{
+		time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 9, 30, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 2: Use the last day of the months without the 31st.
+	////
+
+	actual = GetDatesForExactDayByMonthlyBasedRecurringScheduleWithPolicy(startDateTime, 4, 31, UseLastDayForMissing)
+	expected = []time.Time{
+		time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 9, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 9, 30, 0, 0, time.UTC),
+		time.Date(2024, 4, 30, 9, 30, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 3: Dates before the starting date are not included.
+	////
+
+	actual = GetDatesForExactDayByMonthlyBasedRecurringScheduleWithPolicy(startDateTime, 2, 10, UseLastDayForMissing)
+	expected = []time.Time{
+		time.Date(2024, 2, 10, 9, 30, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	cases := []struct {
+		dt       time.Time
+		months   int
+		expected time.Time
+	}{
+		{time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), 1, time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)},
+		{time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC), 1, time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), -1, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 2, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), 13, time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		if actual := AddMonthsClamped(tc.dt, tc.months); !actual.Equal(tc.expected) {
+			t.Errorf("Incorrect date for %s plus %d months, got %s but was expecting %s", tc.dt, tc.months, actual, tc.expected)
+		}
+	}
+
+	actual := AddYearsClamped(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 1)
+	if expected := time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+	actual = AddYearsClamped(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 4)
+	if expected := time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestAddMonthsAnchored(t *testing.T) {
+	// Developers Note:
+	// Adding one month at a time returns to the 31st whenever the month has
+	// one, unlike `AddMonthsClamped` which would stay on the 29th.
+	dt := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	expected := []time.Time{
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+	}
+	for _, e := range expected {
+		dt = AddMonthsAnchored(dt, 1, 31)
+		if !dt.Equal(e) {
+			t.Errorf("Incorrect date, got %s but was expecting %s", dt, e)
+		}
+	}
+	if actual := AddMonthsClamped(AddMonthsClamped(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), 1), 1); actual.Day() != 29 {
+		t.Errorf("Incorrect clamped date, got %s but was expecting the 29th", actual)
+	}
+}
+
 func TestGetDatesForFirstDayByMonthlyBasedRecurringSchedule(t *testing.T) {
 	////
 	//// Case 1: Test.
@@ -750,3 +880,255 @@ func TestHourRangeForNow(t *testing.T) {
 		t.Errorf("Incorrect date, got %s but was expecting %s", act2, exp2)
 	}
 }
+
+func TestWeeklyBasedRecurringSchedule(t *testing.T) {
+	weekdays := []int8{1, 3}                                     // 1=Monday, 3=Wednesday
+	startDateTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC) // Jan 1st 2023
+
+	// Ten years of weekly dates but we only want the first few, so the lazy
+	// iterator allows us to stop early.
+	var actual []time.Time
+	for dt := range WeeklyBasedRecurringSchedule(startDateTime, weekdays, 520, 1) {
+		actual = append(actual, dt)
+		if len(actual) == 3 {
+			break
+		}
+	}
+	expected := []time.Time{
+		time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), // Monday
+		time.Date(2023, 1, 4, 0, 0, 0, 0, time.UTC), // Wednesday
+		time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC), // Monday
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestGetDatesForFirstWeekDayByMonthlyBasedRecurringScheduleAcrossYears(t *testing.T) {
+	// Developers Note:
+	// This verifies our schedule continues from December into January.
+	startDateTime := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)                         // Nov 1st 2023
+	actual := GetDatesForFirstWeekDayByMonthlyBasedRecurringSchedule(startDateTime, 4, 1) // 1 = Monday
+	expected := []time.Time{
+		time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestGetDatesForNthWeekDayByMonthlyBasedRecurringSchedule(t *testing.T) {
+	////
+	//// Case 1: Second Tuesday of the month across the new year.
+	////
+
+	startDateTime := time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC) // Nov 1st 2023 - 10 AM
+	actual := GetDatesForNthWeekDayByMonthlyBasedRecurringSchedule(startDateTime, 4, 2, 2, SkipMissingWeekday)
+	expected := []time.Time{
+		time.Date(2023, 11, 14, 10, 0, 0, 0, time.UTC),
+		time.Date(2023, 12, 12, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 9, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 13, 10, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 2: Fifth Friday of the month, skipping months without one.
+	////
+
+	startDateTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Jan 1st 2024
+	actual = GetDatesForNthWeekDayByMonthlyBasedRecurringSchedule(startDateTime, 4, 5, 5, SkipMissingWeekday)
+	expected = []time.Time{
+		time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 3: Fifth Friday of the month, falling back to the last Friday.
+	////
+
+	actual = GetDatesForNthWeekDayByMonthlyBasedRecurringSchedule(startDateTime, 4, 5, 5, UseLastWeekdayForMissing)
+	expected = []time.Time{
+		time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 4: Second last Thursday and dates before the start are skipped.
+	////
+
+	startDateTime = time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC) // Feb 23rd 2024
+	actual = GetDatesForNthWeekDayByMonthlyBasedRecurringSchedule(startDateTime, 3, -2, 4, SkipMissingWeekday)
+	expected = []time.Time{
+		time.Date(2024, 3, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 18, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 5: Invalid ordinals return nothing.
+	////
+
+	actual = GetDatesForNthWeekDayByMonthlyBasedRecurringSchedule(startDateTime, 3, 6, 4, SkipMissingWeekday)
+	if len(actual) != 0 {
+		t.Errorf("Incorrect date, got %s but was expecting none", actual)
+	}
+}
+
+func TestGetDatesForExactDayByYearlyBasedRecurringSchedule(t *testing.T) {
+	////
+	//// Case 1: Every year with a count.
+	////
+
+	startDateTime := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC) // Jun 1st 2024 - 9 AM
+	actual := GetDatesForExactDayByYearlyBasedRecurringSchedule(startDateTime, 3, 15, 1, 3, time.Time{}, SkipLeapDay)
+	expected := []time.Time{
+		time.Date(2025, 3, 15, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC),
+		time.Date(2027, 3, 15, 9, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 2: Every two years with an until date.
+	////
+
+	startDateTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) // Jan 1st 2020
+	untilDateTime := time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC)
+	actual = GetDatesForExactDayByYearlyBasedRecurringSchedule(startDateTime, 7, 4, 2, 0, untilDateTime, SkipLeapDay)
+	expected = []time.Time{
+		time.Date(2020, 7, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 7, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 3: Feb 29th with each of the leap day policies.
+	////
+
+	startDateTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Jan 1st 2024
+	untilDateTime = time.Date(2028, 12, 31, 0, 0, 0, 0, time.UTC)
+	actual = GetDatesForExactDayByYearlyBasedRecurringSchedule(startDateTime, 2, 29, 1, 0, untilDateTime, SkipLeapDay)
+	expected = []time.Time{
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	actual = GetDatesForExactDayByYearlyBasedRecurringSchedule(startDateTime, 2, 29, 1, 3, time.Time{}, UseFeb28ForLeapDay)
+	expected = []time.Time{
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	actual = GetDatesForExactDayByYearlyBasedRecurringSchedule(startDateTime, 2, 29, 1, 3, time.Time{}, UseMar1ForLeapDay)
+	expected = []time.Time{
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 4: Invalid dates and missing end conditions return nothing.
+	////
+
+	actual = GetDatesForExactDayByYearlyBasedRecurringSchedule(startDateTime, 4, 31, 1, 3, time.Time{}, SkipLeapDay)
+	if len(actual) != 0 {
+		t.Errorf("Incorrect date, got %s but was expecting none", actual)
+	}
+	actual = GetDatesForExactDayByYearlyBasedRecurringSchedule(startDateTime, 4, 1, 1, 0, time.Time{}, SkipLeapDay)
+	if len(actual) != 0 {
+		t.Errorf("Incorrect date, got %s but was expecting none", actual)
+	}
+}
+
+func TestGetDatesForNthWeekDayByYearlyBasedRecurringSchedule(t *testing.T) {
+	////
+	//// Case 1: Last Friday of November.
+	////
+
+	startDateTime := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC) // Dec 1st 2023
+	actual := GetDatesForNthWeekDayByYearlyBasedRecurringSchedule(startDateTime, 11, -1, 5, 1, 3, time.Time{}, SkipMissingWeekday)
+	expected := []time.Time{
+		time.Date(2024, 11, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 2: Fourth Thursday of November every two years until a date.
+	////
+
+	untilDateTime := time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC)
+	actual = GetDatesForNthWeekDayByYearlyBasedRecurringSchedule(startDateTime, 11, 4, 4, 2, 0, untilDateTime, SkipMissingWeekday)
+	expected = []time.Time{
+		time.Date(2025, 11, 27, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, 11, 25, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 3: Fifth Monday of February with each missing weekday policy.
+	////
+
+	startDateTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Jan 1st 2024
+	actual = GetDatesForNthWeekDayByYearlyBasedRecurringSchedule(startDateTime, 2, 5, 1, 1, 2, time.Time{}, UseLastWeekdayForMissing)
+	expected = []time.Time{
+		time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	untilDateTime = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	actual = GetDatesForNthWeekDayByYearlyBasedRecurringSchedule(startDateTime, 2, 5, 4, 1, 0, untilDateTime, SkipMissingWeekday)
+	expected = []time.Time{
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+	}
+	if timeEqual(expected, actual) == false {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestGetDatesByWeeklyBasedRecurringScheduleWithZeroFrequency(t *testing.T) {
+	startDateTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	actual := GetDatesByWeeklyBasedRecurringSchedule(startDateTime, []int8{1}, 4, 0)
+	if len(actual) != 0 {
+		t.Errorf("Incorrect date, got %s but was expecting none", actual)
+	}
+}
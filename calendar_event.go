@@ -0,0 +1,619 @@
+package timekit
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCalendarEvent is returned (wrapped with more details) when a
+// systemd calendar event expression cannot be parsed.
+var ErrInvalidCalendarEvent = errors.New("timekit: invalid calendar event")
+
+// calendarEventShortcuts is a mapping of the named shortcuts to their
+// expressions as documented by systemd.time(7).
+var calendarEventShortcuts = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+}
+
+// calendarEventWeekdays are the weekday abbreviations in the order systemd
+// prints them, which starts the week on Monday.
+var calendarEventWeekdays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// calendarChain is a single comma separated value of a calendar event
+// component, for example `10`, `1..5` or `00/15`. A `stop` of -1 means there
+// is no range and a `repeat` of 0 means there is no repetition.
+type calendarChain struct {
+	start  int
+	stop   int
+	repeat int
+}
+
+// calendarComponent is the list of values of a calendar event component, nil
+// means every value (`*`).
+type calendarComponent []calendarChain
+
+// matches returns true if the value is allowed by the component.
+func (cc calendarComponent) matches(value int) bool {
+	if cc == nil {
+		return true
+	}
+	for _, ch := range cc {
+		switch {
+		case ch.repeat > 0:
+			if value >= ch.start && (ch.stop < 0 || value <= ch.stop) && (value-ch.start)%ch.repeat == 0 {
+				return true
+			}
+		case ch.stop >= 0:
+			if value >= ch.start && value <= ch.stop {
+				return true
+			}
+		case value == ch.start:
+			return true
+		}
+	}
+	return false
+}
+
+// matchesFromEnd is like `matches` for days counted back from the last day
+// of the month, where the repetitions count down toward the last day, so
+// `~07/2` is the 7th, 5th, 3rd and last day from the end.
+func (cc calendarComponent) matchesFromEnd(value int) bool {
+	if cc == nil {
+		return true
+	}
+	for _, ch := range cc {
+		if ch.repeat == 0 {
+			if (calendarComponent{ch}).matches(value) {
+				return true
+			}
+			continue
+		}
+		from, to := ch.start, 1
+		if ch.stop >= 0 {
+			from, to = ch.stop, ch.start
+		}
+		if value <= from && value >= to && (from-value)%ch.repeat == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// bits returns a bitset of the values from `lo` to `hi` allowed by the
+// component.
+func (cc calendarComponent) bits(lo int, hi int) uint64 {
+	var result uint64
+	for v := lo; v <= hi; v++ {
+		if cc.matches(v) {
+			result |= 1 << v
+		}
+	}
+	return result
+}
+
+// format returns the normalized form of the component with the values padded
+// to the width.
+func (cc calendarComponent) format(width int) string {
+	if cc == nil {
+		return "*"
+	}
+	parts := make([]string, len(cc))
+	for i, ch := range cc {
+		s := fmt.Sprintf("%0*d", width, ch.start)
+		if ch.stop >= 0 {
+			s += fmt.Sprintf("..%0*d", width, ch.stop)
+		}
+		if ch.repeat > 0 {
+			s += "/" + strconv.Itoa(ch.repeat)
+		}
+		parts[i] = s
+	}
+	return strings.Join(parts, ",")
+}
+
+// CalendarEvent represents a systemd calendar event expression, as used by
+// the `OnCalendar=` setting of timers, for example `Mon..Fri *-*-* 09:00` or
+// `*-*-* 00/15:00`. The expression has the form
+//
+//	[Weekdays] [[Year-]Month-Day] [Hour:Minute[:Second]] [Timezone]
+//
+// where the weekdays are a list or `..` range of names (ex: `Mon,Wed..Fri`)
+// and every other component is `*`, a list, a `..` range or a `/` repetition
+// (ex: `00/15` is every 15 starting at 0). Using `~` instead of the `-` before
+// the day counts back from the last day of the month, so `*-02~01` is the last
+// day of February. The shortcuts `minutely`, `hourly`, `daily`, `weekly`,
+// `monthly`, `quarterly`, `semiannually`, `yearly` and `annually` are also
+// supported. Fractional seconds are not supported.
+//
+// Daylight saving time is handled the same way as the `Cron` struct.
+type CalendarEvent struct {
+	weekdays   uint8
+	year       calendarComponent
+	month      calendarComponent
+	day        calendarComponent
+	endOfMonth bool
+	hour       calendarComponent
+	minute     calendarComponent
+	second     calendarComponent
+	loc        *time.Location
+	zone       string
+
+	// The bitsets of the allowed values which are built after parsing.
+	monthBits  uint64
+	dayBits    uint64
+	hourBits   uint64
+	minuteBits uint64
+	secondBits uint64
+}
+
+// ParseCalendarEvent converts a systemd calendar event expression into a
+// `CalendarEvent`. The timezone suffix of the expression (ex: "UTC" or
+// "Europe/Berlin") picks the location, otherwise the inputted location is
+// used, or the local time if it is nil.
+func ParseCalendarEvent(s string, loc *time.Location) (*CalendarEvent, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	e := &CalendarEvent{loc: loc}
+
+	tokens := strings.Fields(s)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty expression", ErrInvalidCalendarEvent)
+	}
+
+	// Developers Note:
+	// The timezone is the last token, but a lone token is never a timezone
+	// so that shortcuts like "daily" are not looked up as a location.
+	if len(tokens) > 1 && isCalendarEventZone(tokens[len(tokens)-1]) {
+		name := tokens[len(tokens)-1]
+		zone, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidCalendarEvent, name)
+		}
+		e.loc, e.zone = zone, name
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 1 {
+		if expanded, ok := calendarEventShortcuts[strings.ToLower(tokens[0])]; ok {
+			tokens = strings.Fields(expanded)
+		}
+	}
+
+	e.weekdays = 0x7f
+	first := tokens[0]
+	if first[0] >= 'A' && first[0] <= 'Z' || first[0] >= 'a' && first[0] <= 'z' {
+		weekdays, err := parseCalendarEventWeekdays(first)
+		if err != nil {
+			return nil, err
+		}
+		e.weekdays = weekdays
+		tokens = tokens[1:]
+	}
+
+	var date, clock string
+	switch len(tokens) {
+	case 0:
+	case 1:
+		if strings.Contains(tokens[0], ":") {
+			clock = tokens[0]
+		} else {
+			date = tokens[0]
+		}
+	case 2:
+		date, clock = tokens[0], tokens[1]
+	default:
+		return nil, fmt.Errorf("%w: too many parts in %q", ErrInvalidCalendarEvent, s)
+	}
+	if err := e.parseDate(date); err != nil {
+		return nil, err
+	}
+	if err := e.parseTime(clock); err != nil {
+		return nil, err
+	}
+
+	e.monthBits = e.month.bits(1, 12)
+	e.dayBits = e.day.bits(1, 31)
+	if e.endOfMonth {
+		e.dayBits = 0
+		for v := 1; v <= 31; v++ {
+			if e.day.matchesFromEnd(v) {
+				e.dayBits |= 1 << v
+			}
+		}
+	}
+	e.hourBits = e.hour.bits(0, 23)
+	e.minuteBits = e.minute.bits(0, 59)
+	e.secondBits = e.second.bits(0, 59)
+	return e, nil
+}
+
+// isCalendarEventZone returns true if the token is a timezone and not part of
+// the calendar event.
+func isCalendarEventZone(token string) bool {
+	if strings.ContainsAny(token, ":*,~") || (token[0] >= '0' && token[0] <= '9') {
+		return false
+	}
+	if _, err := parseCalendarEventWeekdays(token); err == nil {
+		return false
+	}
+	return true
+}
+
+// String returns the normalized form of the expression, the same way as the
+// `systemd-analyze calendar` command, for example `Mon..Fri *-*-* 09:00:00`.
+func (e *CalendarEvent) String() string {
+	var sb strings.Builder
+	if e.weekdays != 0x7f {
+		sb.WriteString(formatCalendarEventWeekdays(e.weekdays))
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(e.year.format(4))
+	sb.WriteByte('-')
+	sb.WriteString(e.month.format(2))
+	if e.endOfMonth {
+		sb.WriteByte('~')
+	} else {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(e.day.format(2))
+	sb.WriteByte(' ')
+	sb.WriteString(e.hour.format(2))
+	sb.WriteByte(':')
+	sb.WriteString(e.minute.format(2))
+	sb.WriteByte(':')
+	sb.WriteString(e.second.format(2))
+	if e.zone != "" {
+		sb.WriteByte(' ')
+		sb.WriteString(e.zone)
+	}
+	return sb.String()
+}
+
+// Location returns the location the calendar event elapses in.
+func (e *CalendarEvent) Location() *time.Location {
+	return e.loc
+}
+
+// Next returns the first time the calendar event elapses strictly after the
+// inputted time or the zero time if it never elapses again.
+func (e *CalendarEvent) Next(after time.Time) time.Time {
+	wall := wallClockOf(after.In(e.loc)).Truncate(time.Second).Add(time.Second)
+	for {
+		next, ok := e.nextWall(wall)
+		if !ok {
+			return time.Time{}
+		}
+		if dt := resolveScheduledWallClock(next, e.loc); dt.After(after) {
+			return dt
+		}
+		wall = next.Add(time.Second)
+	}
+}
+
+// NextN returns the next `n` times the calendar event elapses strictly after
+// the inputted time, like the `--iterations` option of `systemd-analyze
+// calendar`. Fewer times are returned if the calendar event stops elapsing.
+func (e *CalendarEvent) NextN(after time.Time, n int) []time.Time {
+	results := []time.Time{}
+	for dt := e.Next(after); !dt.IsZero() && len(results) < n; dt = e.Next(dt) {
+		results = append(results, dt)
+	}
+	return results
+}
+
+// Occurrences returns an iterator which lazily yields the times the calendar
+// event elapses inside the half-open `[start, end)` range.
+func (e *CalendarEvent) Occurrences(start time.Time, end time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for dt := e.Next(start.Add(-time.Nanosecond)); !dt.IsZero() && dt.Before(end); dt = e.Next(dt) {
+			if !yield(dt) {
+				return
+			}
+		}
+	}
+}
+
+// nextWall returns the first local time (stored in UTC) at or after the
+// inputted one which matches every component, the same way as the cron.
+func (e *CalendarEvent) nextWall(wall time.Time) (time.Time, bool) {
+	for wall.Year() <= rruleMaxYear {
+		year, month, day := wall.Date()
+		hour, minute, second := wall.Clock()
+
+		if !e.year.matches(year) {
+			wall = time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		m, ok := nextCronBit(e.monthBits, int(month), 12)
+		if !ok {
+			wall = time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if time.Month(m) != month {
+			wall = time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		d, ok := nextCronBit(e.daysOfMonth(year, month), day, daysIn(year, month))
+		if !ok {
+			wall = time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if d != day {
+			wall = time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		h, ok := nextCronBit(e.hourBits, hour, 23)
+		if !ok {
+			wall = time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if h != hour {
+			wall = time.Date(year, month, day, h, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		mi, ok := nextCronBit(e.minuteBits, minute, 59)
+		if !ok {
+			wall = time.Date(year, month, day, hour+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if mi != minute {
+			wall = time.Date(year, month, day, hour, mi, 0, 0, time.UTC)
+			continue
+		}
+
+		sec, ok := nextCronBit(e.secondBits, second, 59)
+		if !ok {
+			wall = time.Date(year, month, day, hour, minute+1, 0, 0, time.UTC)
+			continue
+		}
+		return time.Date(year, month, day, hour, minute, sec, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+// daysOfMonth returns a bitset of the days of the month which match both the
+// day and the weekdays of the calendar event.
+func (e *CalendarEvent) daysOfMonth(year int, month time.Month) uint64 {
+	lastDay := daysIn(year, month)
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+
+	var result uint64
+	for day := 1; day <= lastDay; day++ {
+		value := day
+		if e.endOfMonth {
+			value = lastDay - day + 1
+		}
+		if e.dayBits&(1<<value) == 0 {
+			continue
+		}
+		// Developers Note:
+		// Our weekday bits start the week on Monday like systemd does.
+		weekday := (int(first) + day - 1) % 7
+		if e.weekdays&(1<<((weekday+6)%7)) != 0 {
+			result |= 1 << day
+		}
+	}
+	return result
+}
+
+// parseDate parses the `[Year-]Month-Day` or `[Year-]Month~Day` part.
+func (e *CalendarEvent) parseDate(s string) error {
+	if s == "" {
+		return nil
+	}
+	invalid := fmt.Errorf("%w: invalid date %q", ErrInvalidCalendarEvent, s)
+
+	var parts []string
+	if before, after, ok := strings.Cut(s, "~"); ok {
+		e.endOfMonth = true
+		parts = append(strings.Split(before, "-"), after)
+	} else {
+		parts = strings.Split(s, "-")
+	}
+
+	var err error
+	switch len(parts) {
+	case 2:
+	case 3:
+		if e.year, err = parseCalendarComponent(parts[0], 0, 9999); err != nil {
+			return invalid
+		}
+		// Developers Note:
+		// Like systemd we treat two digit years as 1970 to 2069.
+		for i := range e.year {
+			e.year[i].start = expandCalendarEventYear(e.year[i].start)
+			if e.year[i].stop >= 0 {
+				e.year[i].stop = expandCalendarEventYear(e.year[i].stop)
+			}
+		}
+		parts = parts[1:]
+	default:
+		return invalid
+	}
+	if e.month, err = parseCalendarComponent(parts[0], 1, 12); err != nil {
+		return invalid
+	}
+	if e.day, err = parseCalendarComponent(parts[1], 1, 31); err != nil {
+		return invalid
+	}
+	return nil
+}
+
+// expandCalendarEventYear converts a two digit year into a four digit year.
+func expandCalendarEventYear(year int) int {
+	switch {
+	case year < 70:
+		return year + 2000
+	case year < 100:
+		return year + 1900
+	}
+	return year
+}
+
+// parseTime parses the `Hour:Minute[:Second]` part, which is midnight when
+// it is not set.
+func (e *CalendarEvent) parseTime(s string) error {
+	if s == "" {
+		s = "00:00:00"
+	}
+	invalid := fmt.Errorf("%w: invalid time %q", ErrInvalidCalendarEvent, s)
+
+	parts := strings.Split(s, ":")
+	switch len(parts) {
+	case 2:
+		parts = append(parts, "00")
+	case 3:
+	default:
+		return invalid
+	}
+	var err error
+	if e.hour, err = parseCalendarComponent(parts[0], 0, 23); err != nil {
+		return invalid
+	}
+	if e.minute, err = parseCalendarComponent(parts[1], 0, 59); err != nil {
+		return invalid
+	}
+	if e.second, err = parseCalendarComponent(parts[2], 0, 59); err != nil {
+		return invalid
+	}
+	return nil
+}
+
+// parseCalendarComponent parses a comma separated list of values, `..`
+// ranges and `/` repetitions. The values are sorted and duplicates removed
+// the same way systemd normalizes them.
+func parseCalendarComponent(s string, lo int, hi int) (calendarComponent, error) {
+	if s == "*" {
+		return nil, nil
+	}
+	parseValue := func(v string) (int, error) {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < lo || n > hi {
+			return 0, ErrInvalidCalendarEvent
+		}
+		return n, nil
+	}
+
+	result := calendarComponent{}
+	for _, item := range strings.Split(s, ",") {
+		ch := calendarChain{stop: -1}
+		value, repeat, hasRepeat := strings.Cut(item, "/")
+		if hasRepeat {
+			r, err := strconv.Atoi(repeat)
+			if err != nil || r < 1 {
+				return nil, ErrInvalidCalendarEvent
+			}
+			ch.repeat = r
+		}
+		start, stop, isRange := strings.Cut(value, "..")
+		var err error
+		if start == "*" && hasRepeat && !isRange {
+			ch.start = lo
+		} else if ch.start, err = parseValue(start); err != nil {
+			return nil, err
+		}
+		if isRange {
+			if ch.stop, err = parseValue(stop); err != nil || ch.stop < ch.start {
+				return nil, ErrInvalidCalendarEvent
+			}
+		}
+		result = append(result, ch)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.start != b.start {
+			return a.start < b.start
+		}
+		if a.stop != b.stop {
+			return a.stop < b.stop
+		}
+		return a.repeat < b.repeat
+	})
+	unique := result[:0]
+	for i, ch := range result {
+		if i == 0 || ch != result[i-1] {
+			unique = append(unique, ch)
+		}
+	}
+	return unique, nil
+}
+
+// parseCalendarEventWeekdays parses a list of weekday names and `..` ranges
+// into a bitset which starts the week on Monday.
+func parseCalendarEventWeekdays(s string) (uint8, error) {
+	parseName := func(name string) (int, bool) {
+		name = strings.ToLower(name)
+		for i, abbr := range calendarEventWeekdays {
+			full := strings.ToLower(time.Weekday((i + 1) % 7).String())
+			if name == strings.ToLower(abbr) || name == full {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+
+	var result uint8
+	for _, item := range strings.Split(strings.TrimSuffix(s, ","), ",") {
+		start, stop, isRange := strings.Cut(item, "..")
+		a, ok := parseName(start)
+		if !ok {
+			return 0, fmt.Errorf("%w: invalid weekday %q", ErrInvalidCalendarEvent, item)
+		}
+		b := a
+		if isRange {
+			if b, ok = parseName(stop); !ok {
+				return 0, fmt.Errorf("%w: invalid weekday %q", ErrInvalidCalendarEvent, item)
+			}
+		}
+		for i := a; ; i = (i + 1) % 7 {
+			result |= 1 << i
+			if i == b {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// formatCalendarEventWeekdays returns the weekdays with runs of three or more
+// days written as a `..` range, the same way systemd does.
+func formatCalendarEventWeekdays(weekdays uint8) string {
+	parts := []string{}
+	for i := 0; i < 7; i++ {
+		if weekdays&(1<<i) == 0 {
+			continue
+		}
+		j := i
+		for j+1 < 7 && weekdays&(1<<(j+1)) != 0 {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			parts = append(parts, calendarEventWeekdays[i]+".."+calendarEventWeekdays[j])
+		case j-i == 1:
+			parts = append(parts, calendarEventWeekdays[i], calendarEventWeekdays[j])
+		default:
+			parts = append(parts, calendarEventWeekdays[i])
+		}
+		i = j
+	}
+	return strings.Join(parts, ",")
+}
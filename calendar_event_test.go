@@ -0,0 +1,169 @@
+package timekit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCalendarEventNormalization(t *testing.T) {
+	cases := []struct {
+		expr     string
+		expected string
+	}{
+		{"Sat,Thu,Mon..Wed,Sat..Sun", "Mon..Thu,Sat,Sun *-*-* 00:00:00"},
+		{"Wed *-1", "Wed *-*-01 00:00:00"},
+		{"Wed-Wed,Wed *-1", ""},
+		{"12,14,13,12:20,10,30", "*-*-* 12,13,14:10,20,30:00"},
+		{"mon,fri *-1/2-1,3 *:30:45", "Mon,Fri *-01/2-01,03 *:30:45"},
+		{"*:2/3", "*-*-* *:02/3:00"},
+		{"*-*-* 00/15:00", "*-*-* 00/15:00:00"},
+		{"*-02~03", "*-02~03 00:00:00"},
+		{"Friday..Monday", "Mon,Fri..Sun *-*-* 00:00:00"},
+		{"weekly Pacific/Auckland", "Mon *-*-* 00:00:00 Pacific/Auckland"},
+		{"daily", "*-*-* 00:00:00"},
+		{"quarterly", "*-01,04,07,10-01 00:00:00"},
+		{"Mon,Sun 12-*-* 2,1:23", "Mon,Sun 2012-*-* 01,02:23:00"},
+		{"2003-02..04-05", "2003-02..04-05 00:00:00"},
+		{"Tue 08:30 UTC", "Tue *-*-* 08:30:00 UTC"},
+	}
+	for _, tc := range cases {
+		e, err := ParseCalendarEvent(tc.expr, time.UTC)
+		if tc.expected == "" {
+			if !errors.Is(err, ErrInvalidCalendarEvent) {
+				t.Errorf("%s: was expecting an invalid calendar event error, got %v", tc.expr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", tc.expr, err)
+		}
+		if actual := e.String(); actual != tc.expected {
+			t.Errorf("%s: incorrect normalization, got %q but was expecting %q", tc.expr, actual, tc.expected)
+		}
+	}
+}
+
+func TestCalendarEventNext(t *testing.T) {
+	cases := []struct {
+		expr     string
+		after    time.Time
+		expected time.Time
+	}{
+		{"Mon..Fri *-*-* 09:00", time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)},
+		{"*-*-* 00/15:00", time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)},
+		{"*-*-* 00/15:00", time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"*-02~01", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"*-*~03", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 28, 0, 0, 0, 0, time.UTC)},
+		{"Mon *-05~07/1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 27, 0, 0, 0, 0, time.UTC)},
+		{"Fri *-*-13", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)},
+		{"*-02-29", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"2020-*-* 00:00", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+		{"hourly", time.Date(2024, 12, 31, 23, 0, 0, 1, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		e, err := ParseCalendarEvent(tc.expr, time.UTC)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.expr, err)
+		}
+		if actual := e.Next(tc.after); !actual.Equal(tc.expected) {
+			t.Errorf("%s: incorrect next after %s, got %s but was expecting %s", tc.expr, tc.after, actual, tc.expected)
+		}
+	}
+}
+
+func TestCalendarEventNextN(t *testing.T) {
+	e, err := ParseCalendarEvent("Mon,Wed *-*-* 12:00 Europe/Berlin", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	expected := []time.Time{
+		time.Date(2024, 3, 25, 12, 0, 0, 0, berlin),
+		time.Date(2024, 3, 27, 12, 0, 0, 0, berlin),
+		time.Date(2024, 4, 1, 12, 0, 0, 0, berlin),
+	}
+	actual := e.NextN(time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), 3)
+	if len(actual) != len(expected) {
+		t.Fatalf("incorrect count, got %d but was expecting %d", len(actual), len(expected))
+	}
+	for i := range expected {
+		if !actual[i].Equal(expected[i]) {
+			t.Errorf("incorrect time at %d, got %s but was expecting %s", i, actual[i], expected[i])
+		}
+	}
+}
+
+func TestCalendarEventDaylightSavingTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	////
+	//// Case 1: Spring forward, 02:30 does not exist so it elapses at 03:00.
+	////
+
+	e, err := ParseCalendarEvent("*-*-* 02:30", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := e.Next(time.Date(2024, 3, 10, 0, 0, 0, 0, loc))
+	if expected := time.Date(2024, 3, 10, 3, 0, 0, 0, loc); !actual.Equal(expected) {
+		t.Errorf("incorrect spring forward, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 2: Fall back, 01:30 happens twice but only elapses once.
+	////
+
+	e, err = ParseCalendarEvent("*-*-* 01:30", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 11, 3, 0, 0, 0, 0, loc)
+	actual = e.Next(start)
+	if expected := time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("incorrect fall back, got %s but was expecting %s", actual, expected)
+	}
+	if next := e.Next(actual); !next.Equal(time.Date(2024, 11, 4, 1, 30, 0, 0, loc)) {
+		t.Errorf("incorrect next after fall back, got %s", next)
+	}
+}
+
+func TestCalendarEventOccurrences(t *testing.T) {
+	e, err := ParseCalendarEvent("*-*-01,15 06:00", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := collectTimes(e.Occurrences(time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC)))
+	expected := []time.Time{
+		time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 15, 6, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 15, 6, 0, 0, 0, time.UTC),
+	}
+	if !timeEqual(actual, expected) {
+		t.Errorf("incorrect occurrences, got %v but was expecting %v", actual, expected)
+	}
+}
+
+func TestParseCalendarEventErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"Funday",
+		"*-13-01",
+		"*-*-32",
+		"25:00",
+		"*:60",
+		"*-*-* 00:00 Mars/Olympus",
+		"1-2-3-4",
+		"*-*-* 00:00:00 00:00",
+		"*:05..01",
+		"*:*/0",
+	} {
+		if _, err := ParseCalendarEvent(expr, time.UTC); !errors.Is(err, ErrInvalidCalendarEvent) {
+			t.Errorf("%q: was expecting an invalid calendar event error, got %v", expr, err)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package timekit
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of the current time and of timers, tickers and sleeps.
+// Code which depends on a `Clock` instead of the `time` package directly can
+// be tested with a `FakeClock`. The `Now` method can be passed to every
+// function in this package which takes a `now func() time.Time` parameter,
+// for example `FirstDayOfThisMonth(clock.Now)`.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Since returns the time elapsed since `t`.
+	Since(t time.Time) time.Duration
+
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer creates a timer which sends the current time on its channel
+	// after the duration.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker creates a ticker which sends the current time on its channel
+	// every period. The period must be greater than zero.
+	NewTicker(d time.Duration) Ticker
+
+	// Sleep pauses the current goroutine for the duration.
+	Sleep(d time.Duration)
+}
+
+// Timer is the `Clock` version of `time.Timer`.
+type Timer interface {
+	// C returns the channel the time is delivered on.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing and returns false if it already
+	// fired or was stopped.
+	Stop() bool
+
+	// Reset changes the timer to fire after the duration and returns true if
+	// the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is the `Clock` version of `time.Ticker`.
+type Ticker interface {
+	// C returns the channel the ticks are delivered on.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker, no more ticks will be sent.
+	Stop()
+
+	// Reset stops the ticker and resets its period to the duration.
+	Reset(d time.Duration)
+}
+
+// RealClock is the `Clock` backed by the `time` package.
+var RealClock Clock = realClock{}
+
+// NowFunc returns the `Now` method of the clock as a function which can be
+// passed to the `now func() time.Time` parameter of the functions in this
+// package. A nil clock returns `time.Now`.
+func NowFunc(c Clock) func() time.Time {
+	if c == nil {
+		return time.Now
+	}
+	return c.Now
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+type realTimer struct{ t *time.Timer }
+
+func (rt realTimer) C() <-chan time.Time        { return rt.t.C }
+func (rt realTimer) Stop() bool                 { return rt.t.Stop() }
+func (rt realTimer) Reset(d time.Duration) bool { return rt.t.Reset(d) }
+
+type realTicker struct{ t *time.Ticker }
+
+func (rt realTicker) C() <-chan time.Time   { return rt.t.C }
+func (rt realTicker) Stop()                 { rt.t.Stop() }
+func (rt realTicker) Reset(d time.Duration) { rt.t.Reset(d) }
+
+// FakeClock is a `Clock` whose time only moves when `Advance` or `Set` is
+// called, which makes timers, tickers and sleeps deterministic in tests. It is
+// safe for concurrent use, for example a test can wait for the code under test
+// to start sleeping with `BlockUntil` and then wake it up with `Advance`.
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a pending timer or ticker of the `FakeClock`. A `period` of
+// zero means it is a timer which fires once.
+type fakeWaiter struct {
+	clock    *FakeClock
+	deadline time.Time
+	period   time.Duration
+	ch       chan time.Time
+}
+
+// NewFakeClock is a constructor of the `FakeClock` struct starting at the
+// inputted time.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the current time of the fake clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since returns the time elapsed on the fake clock since `t`.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After returns a channel which receives the fake time once the clock has
+// advanced by the duration.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a timer which fires once the clock has advanced by the
+// duration. A duration of zero or less fires right away.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1)}
+	w.reset(d, 0)
+	return fakeTimer{w}
+}
+
+// NewTicker creates a ticker which fires every time the clock advances by
+// the period. Like `time.NewTicker` this panics if the period is not greater
+// than zero.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("timekit: non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1)}
+	w.reset(d, d)
+	return fakeTicker{w}
+}
+
+// Sleep blocks until the clock has advanced by the duration.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Advance moves the clock forward by the duration and fires every timer and
+// ticker which is due, in the order of their deadlines.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(c.now.Add(d))
+}
+
+// Set moves the clock to the inputted time and fires every timer and ticker
+// which is due, in the order of their deadlines. Moving the clock backwards
+// does not fire anything.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(t)
+}
+
+// BlockUntil blocks until at least `n` timers, tickers or sleeps are waiting
+// on the clock, which lets a test wait for a goroutine to start waiting
+// before it advances the clock.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// setLocked moves the clock to the time and fires the waiters which are due,
+// the caller must hold the lock.
+func (c *FakeClock) setLocked(t time.Time) {
+	for {
+		// Developers Note:
+		// We fire one waiter at a time so a ticker which is due several
+		// times is interleaved correctly with the other waiters and the
+		// clock reads the deadline of the waiter while it fires.
+		var next *fakeWaiter
+		for _, w := range c.waiters {
+			if !w.deadline.After(t) && (next == nil || w.deadline.Before(next.deadline)) {
+				next = w
+			}
+		}
+		if next == nil {
+			break
+		}
+		if next.deadline.After(c.now) {
+			c.now = next.deadline
+		}
+		next.fire(c.now)
+	}
+	c.now = t
+}
+
+// removeLocked removes the waiter from the clock and returns true if it was
+// waiting, the caller must hold the lock.
+func (c *FakeClock) removeLocked(w *fakeWaiter) bool {
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+// fire sends the time without blocking (like the `time` package does, a tick
+// is dropped if the previous one was not received yet) and either schedules
+// the next tick or removes the timer. The caller must hold the lock.
+func (w *fakeWaiter) fire(now time.Time) {
+	select {
+	case w.ch <- now:
+	default:
+	}
+	if w.period > 0 {
+		w.deadline = w.deadline.Add(w.period)
+		return
+	}
+	w.clock.removeLocked(w)
+}
+
+// reset schedules the waiter after the duration and returns true if it was
+// already waiting. A timer which is due right away fires before returning.
+func (w *fakeWaiter) reset(d time.Duration, period time.Duration) bool {
+	c := w.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	active := c.removeLocked(w)
+	w.deadline = c.now.Add(d)
+	w.period = period
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+	if d <= 0 {
+		w.fire(c.now)
+	}
+	return active
+}
+
+// stop removes the waiter from the clock and returns true if it was waiting.
+func (w *fakeWaiter) stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.removeLocked(w)
+}
+
+// fakeTimer is the `Timer` of the `FakeClock`.
+type fakeTimer struct{ w *fakeWaiter }
+
+func (ft fakeTimer) C() <-chan time.Time        { return ft.w.ch }
+func (ft fakeTimer) Stop() bool                 { return ft.w.stop() }
+func (ft fakeTimer) Reset(d time.Duration) bool { return ft.w.reset(d, 0) }
+
+// fakeTicker is the `Ticker` of the `FakeClock`.
+type fakeTicker struct{ w *fakeWaiter }
+
+func (ft fakeTicker) C() <-chan time.Time { return ft.w.ch }
+func (ft fakeTicker) Stop()               { ft.w.stop() }
+func (ft fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("timekit: non-positive interval for Ticker.Reset")
+	}
+	ft.w.reset(d, d)
+}
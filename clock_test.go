@@ -0,0 +1,139 @@
+package timekit
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFakeClockTimers(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	////
+	//// Case 1: Timers fire in the order of their deadlines.
+	////
+
+	late := c.NewTimer(2 * time.Minute)
+	early := c.NewTimer(time.Minute)
+	c.Advance(90 * time.Second)
+	select {
+	case dt := <-early.C():
+		if expected := start.Add(time.Minute); !dt.Equal(expected) {
+			t.Errorf("Incorrect fire time, got %s but was expecting %s", dt, expected)
+		}
+	default:
+		t.Fatal("Incorrect timer, was expecting the early timer to fire")
+	}
+	select {
+	case <-late.C():
+		t.Fatal("Incorrect timer, the late timer fired too soon")
+	default:
+	}
+	if actual := c.Now(); !actual.Equal(start.Add(90 * time.Second)) {
+		t.Errorf("Incorrect now, got %s", actual)
+	}
+	if actual := c.Since(start); actual != 90*time.Second {
+		t.Errorf("Incorrect since, got %s", actual)
+	}
+
+	////
+	//// Case 2: Stopped timers do not fire and reset timers fire later.
+	////
+
+	if !late.Stop() {
+		t.Error("Incorrect stop, was expecting the late timer to be active")
+	}
+	if late.Stop() {
+		t.Error("Incorrect stop, was expecting the late timer to be stopped")
+	}
+	if early.Reset(time.Minute) {
+		t.Error("Incorrect reset, was expecting the early timer to have fired")
+	}
+	c.Set(start.Add(time.Hour))
+	select {
+	case <-late.C():
+		t.Error("Incorrect timer, the stopped timer fired")
+	default:
+	}
+	if dt := <-early.C(); !dt.Equal(start.Add(150 * time.Second)) {
+		t.Errorf("Incorrect fire time of the reset timer, got %s", dt)
+	}
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	ticker := c.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for i := 1; i <= 3; i++ {
+		c.Advance(10 * time.Second)
+		if dt := <-ticker.C(); !dt.Equal(start.Add(time.Duration(i) * 10 * time.Second)) {
+			t.Errorf("Incorrect tick %d, got %s", i, dt)
+		}
+	}
+
+	// Like `time.Ticker` the ticks which are not received are dropped.
+	c.Advance(time.Minute)
+	if dt := <-ticker.C(); !dt.Equal(start.Add(40 * time.Second)) {
+		t.Errorf("Incorrect tick, got %s", dt)
+	}
+	select {
+	case dt := <-ticker.C():
+		t.Errorf("Incorrect tick, was expecting the extra ticks to be dropped but got %s", dt)
+	default:
+	}
+}
+
+func TestFakeClockSleepAndBlockUntil(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	var wg sync.WaitGroup
+	woke := make([]time.Time, 3)
+	for i := range woke {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Sleep(time.Duration(i+1) * time.Second)
+			woke[i] = c.Now()
+		}()
+	}
+	c.BlockUntil(3)
+	c.Advance(time.Second)
+	c.BlockUntil(2)
+	c.Advance(2 * time.Second)
+	wg.Wait()
+	for i, dt := range woke {
+		if dt.Before(start.Add(time.Duration(i+1) * time.Second)) {
+			t.Errorf("Incorrect wake up time for sleeper %d, got %s", i, dt)
+		}
+	}
+
+	select {
+	case <-c.After(0):
+	default:
+		t.Error("Incorrect after, was expecting a zero duration to fire right away")
+	}
+}
+
+func TestClockNowFunc(t *testing.T) {
+	c := NewFakeClock(time.Date(2024, 2, 15, 9, 0, 0, 0, time.UTC))
+	expected := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	if actual := FirstDayOfThisMonth(c.Now); !actual.Equal(expected) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+	if actual := FirstDayOfThisMonth(NowFunc(c)); !actual.Equal(expected) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+	if dt := NowFunc(nil)(); time.Since(dt) > time.Minute {
+		t.Errorf("Incorrect now, got %s", dt)
+	}
+	if dt := RealClock.Now(); time.Since(dt) > time.Minute {
+		t.Errorf("Incorrect now, got %s", dt)
+	}
+	if dt := <-RealClock.After(time.Millisecond); dt.IsZero() {
+		t.Error("Incorrect after, got the zero time")
+	}
+}
@@ -0,0 +1,577 @@
+package timekit
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCron is returned (wrapped with more details) when a cron
+// expression cannot be parsed.
+var ErrInvalidCron = errors.New("timekit: invalid cron expression")
+
+// cronMacros is a mapping of the supported macros to their expressions.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronMonthNames and cronWeekdayNames are the names allowed in place of the
+// numbers of the month and day of week fields.
+var (
+	cronMonthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	cronWeekdayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// Cron represents a parsed cron expression which finds the times a job fires
+// at in a location. For example `0 9 * * MON-FRI` fires at 9 AM every weekday.
+//
+// The expression has five fields (minute, hour, day of month, month and day of
+// week) or six fields with the seconds first. Every field supports `*`, lists
+// (`1,15`), ranges (`1-5`), steps (`*/15` or `10-50/20`) and the month and day
+// of week fields support names (`JAN`, `MON`). The day of week is 0 (Sunday)
+// to 6 (Saturday) and 7 is also Sunday. The Quartz extensions are supported:
+//
+//   - `?` in the day of month or day of week field means no specific value.
+//   - `L` in the day of month field is the last day of the month, `L-3` is
+//     three days before it and `LW` is the last weekday (Monday to Friday).
+//   - `15W` in the day of month field is the weekday nearest to the 15th
+//     without leaving the month.
+//   - `5L` in the day of week field is the last Friday of the month and `5#3`
+//     is the third Friday of the month.
+//
+// The macros `@yearly`, `@annually`, `@monthly`, `@weekly`, `@daily`,
+// `@midnight` and `@hourly` are supported, as is a `CRON_TZ=` (or `TZ=`)
+// prefix to pick the location. When both the day of month and day of week are
+// restricted (neither starts with `*` or `?`) a day matching either fires,
+// like the traditional cron.
+//
+// Daylight saving time is handled like the traditional cron: times skipped by
+// the clocks moving forward fire once at the moment the clocks change and
+// times repeated by the clocks moving back fire only the first time.
+type Cron struct {
+	expr string
+	loc  *time.Location
+
+	second uint64
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domAny and dowAny are true when the field starts with `*` or `?`.
+	domAny bool
+	dowAny bool
+
+	// The Quartz extensions of the day of month and day of week fields.
+	lastDayOffsets []int
+	nearestWeekday []int
+	lastWeekday    bool
+	nthWeekdays    []RRuleWeekday
+}
+
+// ParseCron converts a cron expression (ex: "*/15 9-17 * * MON-FRI") into a
+// `Cron` which fires in the location. A nil location uses the local time
+// unless the expression starts with a `CRON_TZ=` prefix.
+func ParseCron(expr string, loc *time.Location) (*Cron, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	c := &Cron{expr: expr, loc: loc}
+
+	s := strings.TrimSpace(expr)
+	if strings.HasPrefix(s, "CRON_TZ=") || strings.HasPrefix(s, "TZ=") {
+		prefix, rest, _ := strings.Cut(s, " ")
+		_, name, _ := strings.Cut(prefix, "=")
+		zone, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown location %q", ErrInvalidCron, name)
+		}
+		c.loc = zone
+		s = strings.TrimSpace(rest)
+	}
+	if strings.HasPrefix(s, "@") {
+		macro, ok := cronMacros[strings.ToLower(s)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown macro %q", ErrInvalidCron, s)
+		}
+		s = macro
+	}
+
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("%w: expected 5 or 6 fields but got %d", ErrInvalidCron, len(fields))
+	}
+
+	var err error
+	if c.second, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.minute, err = parseCronField(fields[1], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.hour, err = parseCronField(fields[2], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if err = c.parseDayOfMonth(fields[3]); err != nil {
+		return nil, err
+	}
+	if c.month, err = parseCronField(fields[4], 1, 12, cronMonthNames); err != nil {
+		return nil, err
+	}
+	if err = c.parseDayOfWeek(fields[5]); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// String returns the expression the cron was parsed from.
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// Location returns the location the cron fires in.
+func (c *Cron) Location() *time.Location {
+	return c.loc
+}
+
+// Next returns the first time the cron fires strictly after the inputted time
+// or the zero time if it never fires again (ex: February 30th).
+func (c *Cron) Next(after time.Time) time.Time {
+	wall := wallClockOf(after.In(c.loc)).Truncate(time.Second).Add(time.Second)
+	for {
+		next, ok := c.nextWall(wall)
+		if !ok {
+			return time.Time{}
+		}
+		if dt := resolveScheduledWallClock(next, c.loc); dt.After(after) {
+			return dt
+		}
+
+		// Developers Note:
+		// Local times repeated by the clocks moving back fire at the first
+		// instant, so when we are searching from the second instant those
+		// times are behind us and we keep looking.
+		wall = next.Add(time.Second)
+	}
+}
+
+// Prev returns the last time the cron fired strictly before the inputted time
+// or the zero time if it never fired before.
+func (c *Cron) Prev(before time.Time) time.Time {
+	wall := wallClockOf(before.In(c.loc)).Truncate(time.Second)
+
+	// Developers Note:
+	// If our time is the second instant of a repeated local time, every time
+	// of the repeated hour fired before us, so we start from the end of it.
+	if first := resolveScheduledWallClock(wall, c.loc); first.Before(before.Truncate(time.Second)) {
+		wall = wall.Add(before.Truncate(time.Second).Sub(first))
+	}
+	for {
+		prev, ok := c.prevWall(wall)
+		if !ok {
+			return time.Time{}
+		}
+		if dt := resolveScheduledWallClock(prev, c.loc); dt.Before(before) {
+			return dt
+		}
+		wall = prev.Add(-time.Second)
+	}
+}
+
+// Occurrences returns an iterator which lazily yields the times the cron fires
+// inside the half-open `[start, end)` range.
+func (c *Cron) Occurrences(start time.Time, end time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for dt := c.Next(start.Add(-time.Nanosecond)); !dt.IsZero() && dt.Before(end); dt = c.Next(dt) {
+			if !yield(dt) {
+				return
+			}
+		}
+	}
+}
+
+// Between returns the times the cron fires inside the half-open `[start, end)`
+// range.
+func (c *Cron) Between(start time.Time, end time.Time) []time.Time {
+	return collectTimes(c.Occurrences(start, end))
+}
+
+// nextWall returns the first local time (stored in UTC) at or after the
+// inputted one which matches every field of the cron. Each field jumps
+// straight to its next allowed value, and when there is none it carries into
+// the next value of the larger field and the search starts over from there.
+func (c *Cron) nextWall(wall time.Time) (time.Time, bool) {
+	for wall.Year() <= rruleMaxYear {
+		year, month, day := wall.Date()
+		hour, minute, second := wall.Clock()
+
+		m, ok := nextCronBit(c.month, int(month), 12)
+		if !ok {
+			wall = time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if time.Month(m) != month {
+			wall = time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		d, ok := nextCronBit(c.daysOfMonth(year, month), day, daysIn(year, month))
+		if !ok {
+			wall = time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if d != day {
+			wall = time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		h, ok := nextCronBit(c.hour, hour, 23)
+		if !ok {
+			wall = time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if h != hour {
+			wall = time.Date(year, month, day, h, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		mi, ok := nextCronBit(c.minute, minute, 59)
+		if !ok {
+			wall = time.Date(year, month, day, hour+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if mi != minute {
+			wall = time.Date(year, month, day, hour, mi, 0, 0, time.UTC)
+			continue
+		}
+
+		sec, ok := nextCronBit(c.second, second, 59)
+		if !ok {
+			wall = time.Date(year, month, day, hour, minute+1, 0, 0, time.UTC)
+			continue
+		}
+		return time.Date(year, month, day, hour, minute, sec, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+// prevWall returns the last local time (stored in UTC) at or before the
+// inputted one which matches every field of the cron. It works like the
+// `nextWall` function but jumps to the end of the previous allowed values.
+func (c *Cron) prevWall(wall time.Time) (time.Time, bool) {
+	endOf := func(year int, month time.Month, day int, hour int, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC).Add(-time.Second)
+	}
+	for wall.Year() >= 1 {
+		year, month, day := wall.Date()
+		hour, minute, second := wall.Clock()
+
+		m, ok := prevCronBit(c.month, int(month), 1)
+		if !ok {
+			wall = endOf(year, time.January, 1, 0, 0)
+			continue
+		}
+		if time.Month(m) != month {
+			wall = endOf(year, time.Month(m)+1, 1, 0, 0)
+			continue
+		}
+
+		d, ok := prevCronBit(c.daysOfMonth(year, month), day, 1)
+		if !ok {
+			wall = endOf(year, month, 1, 0, 0)
+			continue
+		}
+		if d != day {
+			wall = endOf(year, month, d+1, 0, 0)
+			continue
+		}
+
+		h, ok := prevCronBit(c.hour, hour, 0)
+		if !ok {
+			wall = endOf(year, month, day, 0, 0)
+			continue
+		}
+		if h != hour {
+			wall = endOf(year, month, day, h+1, 0)
+			continue
+		}
+
+		mi, ok := prevCronBit(c.minute, minute, 0)
+		if !ok {
+			wall = endOf(year, month, day, hour, 0)
+			continue
+		}
+		if mi != minute {
+			wall = endOf(year, month, day, hour, mi+1)
+			continue
+		}
+
+		sec, ok := prevCronBit(c.second, second, 0)
+		if !ok {
+			wall = endOf(year, month, day, hour, minute)
+			continue
+		}
+		return time.Date(year, month, day, hour, minute, sec, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+// daysOfMonth returns a bitset of the days of the month which match the day
+// of month and day of week fields.
+func (c *Cron) daysOfMonth(year int, month time.Month) uint64 {
+	lastDay := daysIn(year, month)
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+
+	var domDays, dowDays uint64
+	domDays = c.dom
+	for _, offset := range c.lastDayOffsets {
+		if day := lastDay - offset; day >= 1 {
+			domDays |= 1 << day
+		}
+	}
+	for _, target := range c.nearestWeekday {
+		if target > lastDay {
+			continue
+		}
+		day := target
+		switch (int(first) + day - 1) % 7 {
+		case int(time.Saturday):
+			day--
+			if day < 1 {
+				day = target + 2
+			}
+		case int(time.Sunday):
+			day++
+			if day > lastDay {
+				day = target - 2
+			}
+		}
+		domDays |= 1 << day
+	}
+	if c.lastWeekday {
+		day := lastDay
+		switch (int(first) + day - 1) % 7 {
+		case int(time.Saturday):
+			day--
+		case int(time.Sunday):
+			day -= 2
+		}
+		domDays |= 1 << day
+	}
+	for day := 1; day <= lastDay; day++ {
+		if c.dow&(1<<((int(first)+day-1)%7)) != 0 {
+			dowDays |= 1 << day
+		}
+	}
+	for _, wd := range c.nthWeekdays {
+		if day, ok := nthWeekdayOfMonth(year, month, wd.N, wd.Weekday); ok {
+			dowDays |= 1 << day
+		}
+	}
+
+	if c.domAny || c.dowAny {
+		return domDays & dowDays
+	}
+	return domDays | dowDays
+}
+
+// parseDayOfMonth parses the day of month field including the `L` and `W`
+// extensions.
+func (c *Cron) parseDayOfMonth(field string) error {
+	c.domAny = strings.HasPrefix(field, "*") || field == "?"
+	if field == "?" {
+		c.dom = cronBits(1, 31)
+		return nil
+	}
+	for _, item := range strings.Split(field, ",") {
+		upper := strings.ToUpper(item)
+		switch {
+		case upper == "L":
+			c.lastDayOffsets = append(c.lastDayOffsets, 0)
+		case upper == "LW":
+			c.lastWeekday = true
+		case strings.HasPrefix(upper, "L-"):
+			offset, err := strconv.Atoi(upper[2:])
+			if err != nil || offset < 0 || offset > 30 {
+				return fmt.Errorf("%w: invalid day of month %q", ErrInvalidCron, item)
+			}
+			c.lastDayOffsets = append(c.lastDayOffsets, offset)
+		case strings.HasSuffix(upper, "W"):
+			day, err := strconv.Atoi(upper[:len(upper)-1])
+			if err != nil || day < 1 || day > 31 {
+				return fmt.Errorf("%w: invalid day of month %q", ErrInvalidCron, item)
+			}
+			c.nearestWeekday = append(c.nearestWeekday, day)
+		default:
+			b, err := parseCronField(item, 1, 31, nil)
+			if err != nil {
+				return err
+			}
+			c.dom |= b
+		}
+	}
+	return nil
+}
+
+// parseDayOfWeek parses the day of week field including the `L` and `#`
+// extensions.
+func (c *Cron) parseDayOfWeek(field string) error {
+	c.dowAny = strings.HasPrefix(field, "*") || field == "?"
+	if field == "?" {
+		c.dow = cronBits(0, 6)
+		return nil
+	}
+	parseWeekday := func(s string) (int, bool) {
+		if n, ok := cronWeekdayNames[strings.ToUpper(s)]; ok {
+			return n, true
+		}
+		n, err := strconv.Atoi(s)
+		return n % 7, err == nil && n >= 0 && n <= 7
+	}
+	for _, item := range strings.Split(field, ",") {
+		upper := strings.ToUpper(item)
+		switch {
+		case upper == "L":
+			c.dow |= 1 << time.Saturday
+		case strings.HasSuffix(upper, "L"):
+			wd, ok := parseWeekday(upper[:len(upper)-1])
+			if !ok {
+				return fmt.Errorf("%w: invalid day of week %q", ErrInvalidCron, item)
+			}
+			c.nthWeekdays = append(c.nthWeekdays, RRuleWeekday{Weekday: time.Weekday(wd), N: -1})
+		case strings.Contains(upper, "#"):
+			weekday, nth, _ := strings.Cut(upper, "#")
+			wd, ok := parseWeekday(weekday)
+			n, err := strconv.Atoi(nth)
+			if !ok || err != nil || n < 1 || n > 5 {
+				return fmt.Errorf("%w: invalid day of week %q", ErrInvalidCron, item)
+			}
+			c.nthWeekdays = append(c.nthWeekdays, RRuleWeekday{Weekday: time.Weekday(wd), N: n})
+		default:
+			b, err := parseCronField(item, 0, 7, cronWeekdayNames)
+			if err != nil {
+				return err
+			}
+			// Developers Note:
+			// Both 0 and 7 are Sunday so we fold the 7 onto the 0.
+			if b&(1<<7) != 0 {
+				b = b&^(1<<7) | 1
+			}
+			c.dow |= b
+		}
+	}
+	return nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps
+// into a bitset of the allowed values. A range where the start is after the
+// end wraps around (ex: "FRI-MON" or "22-2" hours).
+func parseCronField(field string, lo int, hi int, names map[string]int) (uint64, error) {
+	invalid := fmt.Errorf("%w: invalid field %q", ErrInvalidCron, field)
+	parseValue := func(s string) (int, bool) {
+		if n, ok := names[strings.ToUpper(s)]; ok {
+			return n, true
+		}
+		n, err := strconv.Atoi(s)
+		return n, err == nil && n >= lo && n <= hi
+	}
+
+	var result uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step < 1 {
+				return 0, invalid
+			}
+		}
+
+		var start, end int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			start, end = lo, hi
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var okA, okB bool
+			start, okA = parseValue(a)
+			end, okB = parseValue(b)
+			if !okA || !okB {
+				return 0, invalid
+			}
+		default:
+			var ok bool
+			if start, ok = parseValue(rangePart); !ok {
+				return 0, invalid
+			}
+			end = start
+			if hasStep {
+				end = hi
+			}
+		}
+
+		span := end - start
+		if span < 0 {
+			span += hi - lo + 1
+		}
+		for i := 0; i <= span; i += step {
+			value := start + i
+			if value > hi {
+				value -= hi - lo + 1
+			}
+			result |= 1 << value
+		}
+	}
+	return result, nil
+}
+
+// cronBits returns a bitset with every value from `lo` to `hi` set.
+func cronBits(lo int, hi int) uint64 {
+	return (1<<(hi+1) - 1) &^ (1<<lo - 1)
+}
+
+// nextCronBit returns the smallest value in the bitset from `from` up to
+// `max`.
+func nextCronBit(set uint64, from int, max int) (int, bool) {
+	if from > max || from > 63 {
+		return 0, false
+	}
+	masked := set & cronBits(from, max)
+	if masked == 0 {
+		return 0, false
+	}
+	return bits.TrailingZeros64(masked), true
+}
+
+// prevCronBit returns the largest value in the bitset from `from` down to
+// `min`.
+func prevCronBit(set uint64, from int, min int) (int, bool) {
+	if from < min || from < 0 {
+		return 0, false
+	}
+	masked := set & cronBits(min, from)
+	if masked == 0 {
+		return 0, false
+	}
+	return 63 - bits.LeadingZeros64(masked), true
+}
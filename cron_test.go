@@ -0,0 +1,162 @@
+package timekit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	cases := []struct {
+		expr     string
+		after    time.Time
+		expected time.Time
+	}{
+		{"*/15 9-17 * * MON-FRI", time.Date(2024, 3, 1, 17, 50, 0, 0, time.UTC), time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)},
+		{"*/15 9-17 * * MON-FRI", time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 9, 15, 0, 0, time.UTC)},
+		{"30 * * * * *", time.Date(2024, 3, 4, 9, 0, 30, 500, time.UTC), time.Date(2024, 3, 4, 9, 1, 30, 0, time.UTC)},
+		{"@daily", time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 L * ?", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 L-2 * ?", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 28, 0, 0, 0, 0, time.UTC)},
+		{"0 0 LW * ?", time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 8, 30, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15W * ?", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1W * ?", time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 ? * 5L", time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 11, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 ? * FRI#3", time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 9, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2024, 9, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)},
+		{"0 0 */2 * 5", time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)},
+		{"0 22-2 * * *", time.Date(2024, 9, 1, 3, 0, 0, 0, time.UTC), time.Date(2024, 9, 1, 22, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+	}
+	for _, tc := range cases {
+		c, err := ParseCron(tc.expr, time.UTC)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.expr, err)
+		}
+		if actual := c.Next(tc.after); !actual.Equal(tc.expected) {
+			t.Errorf("%s: incorrect next after %s, got %s but was expecting %s", tc.expr, tc.after, actual, tc.expected)
+		}
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	cases := []struct {
+		expr     string
+		before   time.Time
+		expected time.Time
+	}{
+		{"*/15 9-17 * * MON-FRI", time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 17, 45, 0, 0, time.UTC)},
+		{"0 0 L * ?", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2024, 1, 1, 0, 0, 0, 1, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 ? * 5L", time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 10, 25, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		c, err := ParseCron(tc.expr, time.UTC)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.expr, err)
+		}
+		if actual := c.Prev(tc.before); !actual.Equal(tc.expected) {
+			t.Errorf("%s: incorrect prev before %s, got %s but was expecting %s", tc.expr, tc.before, actual, tc.expected)
+		}
+	}
+}
+
+func TestCronDaylightSavingTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Developers Note:
+	// The clocks move forward from 2 AM to 3 AM on March 10th 2024, so the
+	// 2:30 AM job fires at 3 AM.
+	c, _ := ParseCron("30 2 * * *", loc)
+	actual := c.Next(time.Date(2024, 3, 10, 0, 0, 0, 0, loc))
+	if expected := time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect spring forward time, got %s but was expecting %s", actual, expected)
+	}
+	actual = c.Next(actual)
+	if expected := time.Date(2024, 3, 11, 2, 30, 0, 0, loc); !actual.Equal(expected) {
+		t.Errorf("Incorrect time after spring forward, got %s but was expecting %s", actual, expected)
+	}
+
+	// Developers Note:
+	// The clocks move back from 2 AM to 1 AM on November 3rd 2024, so the
+	// half hourly job fires during the first 1 AM hour only.
+	c, _ = ParseCron("*/30 * * * *", loc)
+	expected := []time.Time{
+		time.Date(2024, 11, 3, 4, 30, 0, 0, time.UTC), // 12:30 AM EDT
+		time.Date(2024, 11, 3, 5, 0, 0, 0, time.UTC),  // 1:00 AM EDT
+		time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC), // 1:30 AM EDT
+		time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC),  // 2:00 AM EST
+	}
+	actualTimes := c.Between(time.Date(2024, 11, 3, 4, 30, 0, 0, time.UTC), time.Date(2024, 11, 3, 7, 30, 0, 0, time.UTC))
+	if len(actualTimes) != len(expected) {
+		t.Fatalf("Incorrect fall back times, got %s but was expecting %s", actualTimes, expected)
+	}
+	for i := range expected {
+		if !actualTimes[i].Equal(expected[i]) {
+			t.Errorf("Incorrect fall back time, got %s but was expecting %s", actualTimes[i], expected[i])
+		}
+	}
+
+	// From the second 1:45 AM (EST) the previous time is the first 1:30 AM.
+	prev := c.Prev(time.Date(2024, 11, 3, 6, 45, 0, 0, time.UTC))
+	if expected := time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC); !prev.Equal(expected) {
+		t.Errorf("Incorrect prev during fall back, got %s but was expecting %s", prev, expected)
+	}
+	next := c.Next(time.Date(2024, 11, 3, 6, 15, 0, 0, time.UTC))
+	if expected := time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("Incorrect next during fall back, got %s but was expecting %s", next, expected)
+	}
+}
+
+func TestCronOccurrences(t *testing.T) {
+	c, err := ParseCron("CRON_TZ=America/Toronto 0 9 * * 1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Location().String() != "America/Toronto" {
+		t.Errorf("Incorrect location, got %s", c.Location())
+	}
+
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, c.Location())
+	count := 0
+	for dt := range c.Occurrences(start, start.AddDate(1, 0, 0)) {
+		if dt.In(c.Location()).Hour() != 9 || dt.Weekday() != time.Monday {
+			t.Errorf("Incorrect occurrence %s", dt)
+		}
+		if count == 0 && !dt.Equal(start) {
+			t.Errorf("Incorrect first occurrence, got %s but was expecting %s", dt, start)
+		}
+		count++
+	}
+	if count != 53 {
+		t.Errorf("Incorrect number of occurrences, got %d but was expecting 53", count)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"* * L-31 * *",
+		"* * 32W * *",
+		"* * * * 5#6",
+		"@reboot",
+		"CRON_TZ=Nowhere * * * * *",
+	} {
+		if _, err := ParseCron(expr, time.UTC); !errors.Is(err, ErrInvalidCron) {
+			t.Errorf("%q: incorrect error, got %v but was expecting %v", expr, err, ErrInvalidCron)
+		}
+	}
+}
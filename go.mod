@@ -1,6 +1,6 @@
 module github.com/bartmika/timekit
 
-go 1.21.4
+go 1.23
 
 require (
 	github.com/dannav/hhmmss v1.0.0
@@ -0,0 +1,513 @@
+package timekit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalidICSEvent is returned (wrapped with more details) when an event
+// cannot be written into an iCalendar file.
+var ErrInvalidICSEvent = errors.New("timekit: invalid iCalendar event")
+
+// ICSEvent represents a single RFC 5545 `VEVENT` of an iCalendar file. The
+// event can repeat by setting the `RRule` and optionally the `ExDates` and
+// `RDates`, in which case the `Start` and `End` are the first occurrence and
+// single occurrences can be replaced by events with a `RecurrenceID`.
+// Dates/times in UTC are written in UTC while every other location is written
+// with its `TZID` and a matching `VTIMEZONE`, fixed zones without a name use
+// their offset as the `TZID` (ex: "UTC+0200"). The local location is written
+// with its time zone database name found in the `TZ` environment variable or
+// `/etc/localtime`. The `UNTIL` of the `RRule` is always written in UTC for
+// dates/times with a location, as required by RFC 5545.
+type ICSEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+
+	// AllDay writes the start and end as dates without a time of day, in
+	// which case the `End` is the day after the last day of the event.
+	AllDay bool
+
+	RRule   *RRule
+	ExDates []time.Time
+	RDates  []time.Time
+
+	// RecurrenceID is the original start of the occurrence of a repeating
+	// event (with the same `UID`) which this event replaces.
+	RecurrenceID time.Time
+}
+
+// ICSCalendar represents an RFC 5545 `VCALENDAR` which can be written to an
+// iCalendar (.ics) file and subscribed to by calendar applications.
+type ICSCalendar struct {
+	// ProductID is the `PRODID` identifying the application which created
+	// the calendar.
+	ProductID string
+
+	// Events are the events written into the calendar.
+	Events []ICSEvent
+
+	// Now returns the date/time used for the `DTSTAMP` of the events.
+	Now func() time.Time
+}
+
+// NewICSCalendar is a constructor of the `ICSCalendar` struct.
+func NewICSCalendar(productID string) *ICSCalendar {
+	return &ICSCalendar{
+		ProductID: productID,
+		Events:    []ICSEvent{},
+		Now:       time.Now,
+	}
+}
+
+// AddEvent appends the event into the calendar.
+func (c *ICSCalendar) AddEvent(event ICSEvent) {
+	c.Events = append(c.Events, event)
+}
+
+// AddTimeRanges appends an event into the calendar for every range, for
+// example the ranges returned by the `DailyRangesBetweenTimes` function. The
+// `uid` function returns the unique identifier of the range's event.
+func (c *ICSCalendar) AddTimeRanges(ranges []*TimeRange, uid func(tr *TimeRange) string, summary string, description string) {
+	for _, tr := range ranges {
+		c.AddEvent(ICSEvent{
+			UID:         uid(tr),
+			Summary:     summary,
+			Description: description,
+			Start:       tr.Start,
+			End:         tr.End,
+		})
+	}
+}
+
+// String returns the calendar in the iCalendar format or an empty string if
+// the calendar has an invalid event.
+func (c *ICSCalendar) String() string {
+	var sb strings.Builder
+	if _, err := c.WriteTo(&sb); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+// WriteTo writes the calendar in the iCalendar format into the writer.
+func (c *ICSCalendar) WriteTo(w io.Writer) (int64, error) {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	stamp := now().UTC().Format("20060102T150405Z")
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + escapeICSText(c.ProductID),
+		"CALSCALE:GREGORIAN",
+	}
+
+	// Developers Note:
+	// Every location used by the events needs a `VTIMEZONE` which covers all
+	// of the years that the events use.
+	zones := map[string]*icsZoneWindow{}
+	events := make([]ICSEvent, len(c.Events))
+	for i, event := range c.Events {
+		if event.Start.Location() == time.Local {
+			loc, err := icsLocalLocation()
+			if err != nil {
+				return 0, fmt.Errorf("%w: event %q: %w", ErrInvalidICSEvent, event.UID, err)
+			}
+			event.Start = event.Start.In(loc)
+		}
+		events[i] = event
+
+		if event.UID == "" {
+			return 0, fmt.Errorf("%w: event %d does not have a UID", ErrInvalidICSEvent, i)
+		}
+		if event.End.Before(event.Start) {
+			return 0, fmt.Errorf("%w: event %q ends before it starts", ErrInvalidICSEvent, event.UID)
+		}
+		if event.AllDay || !hasICSTimeZone(event.Start.Location()) {
+			continue
+		}
+		name := icsTimeZoneName(event.Start.Location())
+		zone, ok := zones[name]
+		if !ok {
+			zone = &icsZoneWindow{loc: event.Start.Location(), from: event.Start.Year(), to: event.Start.Year()}
+			zones[name] = zone
+		}
+		for _, dt := range append([]time.Time{event.Start, event.End}, event.RDates...) {
+			zone.from = min(zone.from, dt.Year())
+			zone.to = max(zone.to, dt.Year())
+		}
+		if event.RRule != nil {
+			// Developers Note:
+			// Rules without an until date go on forever, so we cover the
+			// following year and the observances repeat from then on.
+			zone.to = max(zone.to, event.Start.Year()+1)
+			if !event.RRule.Until.IsZero() {
+				zone.to = max(zone.to, event.RRule.Until.Year())
+			}
+		}
+	}
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		lines = append(lines, zones[name].lines()...)
+	}
+
+	for _, event := range events {
+		lines = append(lines, "BEGIN:VEVENT", "UID:"+escapeICSText(event.UID), "DTSTAMP:"+stamp)
+		lines = append(lines, formatICSDateTime("DTSTART", event.Start, event.AllDay))
+		lines = append(lines, formatICSDateTime("DTEND", event.End.In(event.Start.Location()), event.AllDay))
+		if event.Summary != "" {
+			lines = append(lines, "SUMMARY:"+escapeICSText(event.Summary))
+		}
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+escapeICSText(event.Description))
+		}
+		if !event.RecurrenceID.IsZero() {
+			lines = append(lines, formatICSDateTime("RECURRENCE-ID", event.RecurrenceID.In(event.Start.Location()), event.AllDay))
+		}
+		if event.RRule != nil {
+			lines = append(lines, "RRULE:"+icsRRule(event).String())
+		}
+		for _, dt := range event.ExDates {
+			lines = append(lines, formatICSDateTime("EXDATE", dt.In(event.Start.Location()), event.AllDay))
+		}
+		for _, dt := range event.RDates {
+			lines = append(lines, formatICSDateTime("RDATE", dt.In(event.Start.Location()), event.AllDay))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var total int64
+	for _, line := range lines {
+		n, err := io.WriteString(w, foldICSLine(line))
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// hasICSTimeZone returns true if the location needs to be written with a
+// `TZID`, otherwise the date/time is written in UTC.
+func hasICSTimeZone(loc *time.Location) bool {
+	return loc != time.UTC && loc.String() != "UTC"
+}
+
+// icsLocalLocation returns the local location loaded by its time zone
+// database name, since `time.Local` is only named "Local" which calendar
+// applications cannot look up. Go finds the local time zone in the `TZ`
+// environment variable or else in the `/etc/localtime` file, so we do too.
+func icsLocalLocation() (*time.Location, error) {
+	path, ok := os.LookupEnv("TZ")
+	if ok {
+		path = strings.TrimPrefix(path, ":")
+		if path == "" {
+			return time.UTC, nil
+		}
+	} else {
+		var err error
+		if path, err = filepath.EvalSymlinks("/etc/localtime"); err != nil {
+			path = ""
+		}
+	}
+	if i := strings.LastIndex(path, "zoneinfo/"); i >= 0 {
+		path = path[i+len("zoneinfo/"):]
+	}
+	if path != "" && !filepath.IsAbs(path) {
+		if loc, err := time.LoadLocation(path); err == nil {
+			return loc, nil
+		}
+	}
+
+	// Developers Note:
+	// Without a name we can still write the local time in UTC as long as the
+	// local time is UTC, otherwise the local meaning of the event is lost.
+	year := time.Now().Year()
+	_, winter := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local).Zone()
+	_, summer := time.Date(year, time.July, 1, 0, 0, 0, 0, time.Local).Zone()
+	if winter == 0 && summer == 0 {
+		return time.UTC, nil
+	}
+	return nil, errors.New("cannot find the time zone database name of the local time zone")
+}
+
+// icsRRule returns the recurrence rule of the event with its `UNTIL` written
+// the way RFC 5545 requires for the `DTSTART`, that is a date for all day
+// events and otherwise a UTC date/time since we never write floating times.
+func icsRRule(event ICSEvent) *RRule {
+	x := *event.RRule
+	if x.Until.IsZero() {
+		return &x
+	}
+	until, _ := x.untilIn(event.Start.Location())
+	if event.AllDay {
+		until = until.In(event.Start.Location())
+		x.Until = time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC)
+		x.untilFloating, x.untilDateOnly = true, true
+		return &x
+	}
+	x.Until = until.UTC()
+	x.untilFloating, x.untilDateOnly = false, false
+	return &x
+}
+
+// icsTimeZoneName returns the `TZID` of the location. Fixed zones without a
+// name (ex: parsed from an RFC 3339 offset) are named after their offset, for
+// example "UTC+0200", since RFC 5545 does not allow an empty `TZID`.
+func icsTimeZoneName(loc *time.Location) string {
+	if name := loc.String(); name != "" {
+		return name
+	}
+	_, offset := time.Unix(0, 0).In(loc).Zone()
+	return "UTC" + formatICSOffset(offset)
+}
+
+// formatICSDateTime returns the property line of the date/time, for example
+// "DTSTART;TZID=America/Toronto:20240101T090000".
+func formatICSDateTime(name string, dt time.Time, allDay bool) string {
+	switch {
+	case allDay:
+		return name + ";VALUE=DATE:" + dt.Format("20060102")
+	case hasICSTimeZone(dt.Location()):
+		return name + ";TZID=" + icsTimeZoneName(dt.Location()) + ":" + dt.Format("20060102T150405")
+	default:
+		return name + ":" + dt.UTC().Format("20060102T150405Z")
+	}
+}
+
+// escapeICSText escapes the characters which have a special meaning in the
+// RFC 5545 `TEXT` value type.
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// icsMaxLineLength is the maximum number of octets of a content line, not
+// counting the line break, as per RFC 5545 section 3.1.
+const icsMaxLineLength = 75
+
+// foldICSLine splits the content line into lines of at most 75 octets joined
+// by a line break followed by a space, without splitting UTF-8 characters.
+func foldICSLine(line string) string {
+	var sb strings.Builder
+	length := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if length+size > icsMaxLineLength {
+			sb.WriteString("\r\n ")
+			length = 1
+		}
+		sb.WriteRune(r)
+		length += size
+	}
+	sb.WriteString("\r\n")
+	return sb.String()
+}
+
+// icsZoneWindow holds the years of a location which its `VTIMEZONE` needs to
+// cover.
+type icsZoneWindow struct {
+	loc  *time.Location
+	from int
+	to   int
+}
+
+// icsTransition represents a change of the UTC offset of a location.
+type icsTransition struct {
+	at         time.Time
+	name       string
+	offsetFrom int
+	offsetTo   int
+	isDST      bool
+}
+
+// lines returns the `VTIMEZONE` component of the location. Go does not expose
+// the time zone database rules so we find the transitions by looking at the
+// offsets, and then describe transitions which happen on the same weekday of
+// the same month every year with a yearly `RRULE`.
+func (zw *icsZoneWindow) lines() []string {
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + icsTimeZoneName(zw.loc)}
+
+	// Developers Note:
+	// We start from the year before so the observance which is in effect at
+	// the start of the first year is included.
+	transitions := findICSTransitions(zw.loc, zw.from-1, zw.to)
+	if len(transitions) == 0 {
+		name, offset := time.Date(zw.from, 1, 1, 0, 0, 0, 0, zw.loc).Zone()
+		if name == "" {
+			name = icsTimeZoneName(zw.loc)
+		}
+		lines = append(lines,
+			"BEGIN:STANDARD",
+			"DTSTART:19700101T000000",
+			"TZOFFSETFROM:"+formatICSOffset(offset),
+			"TZOFFSETTO:"+formatICSOffset(offset),
+			"TZNAME:"+name,
+			"END:STANDARD",
+		)
+		return append(lines, "END:VTIMEZONE")
+	}
+
+	for _, group := range groupICSTransitions(transitions, zw.to) {
+		first := group.transitions[0]
+		kind := "STANDARD"
+		if first.isDST {
+			kind = "DAYLIGHT"
+		}
+		lines = append(lines,
+			"BEGIN:"+kind,
+			"DTSTART:"+first.at.Add(time.Duration(first.offsetFrom)*time.Second).UTC().Format("20060102T150405"),
+			"TZOFFSETFROM:"+formatICSOffset(first.offsetFrom),
+			"TZOFFSETTO:"+formatICSOffset(first.offsetTo),
+			"TZNAME:"+first.name,
+		)
+		if group.rule != "" {
+			lines = append(lines, "RRULE:"+group.rule)
+		}
+		lines = append(lines, "END:"+kind)
+	}
+	return append(lines, "END:VTIMEZONE")
+}
+
+// findICSTransitions returns the offset transitions of the location between
+// the start of the `from` year and the end of the `to` year.
+func findICSTransitions(loc *time.Location, from int, to int) []icsTransition {
+	transitions := []icsTransition{}
+	end := time.Date(to+1, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := time.Date(from, 1, 1, 0, 0, 0, 0, time.UTC)
+	for prev.Before(end) {
+		// Developers Note:
+		// Every time zone keeps its offset for more than a day, so we look at
+		// the offset once a day and binary search for the exact second once
+		// it has changed.
+		next := prev.Add(24 * time.Hour)
+		prevName, prevOffset := prev.In(loc).Zone()
+		nextName, nextOffset := next.In(loc).Zone()
+		if prevName != nextName || prevOffset != nextOffset {
+			lo, hi := prev.Unix(), next.Unix()
+			for hi-lo > 1 {
+				mid := lo + (hi-lo)/2
+				if name, offset := time.Unix(mid, 0).In(loc).Zone(); name == prevName && offset == prevOffset {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			at := time.Unix(hi, 0).In(loc)
+			transitions = append(transitions, icsTransition{
+				at:         at,
+				name:       nextName,
+				offsetFrom: prevOffset,
+				offsetTo:   nextOffset,
+				isDST:      at.IsDST(),
+			})
+		}
+		prev = next
+	}
+	return transitions
+}
+
+// icsTransitionGroup is a run of yearly transitions which follow the same
+// rule, along with the `RRULE` describing them (empty for a single one).
+type icsTransitionGroup struct {
+	transitions []icsTransition
+	rule        string
+}
+
+// groupICSTransitions groups the transitions which happen every year on the
+// same weekday of the same month at the same local time. A group which is
+// still going in the last year is assumed to keep going forever, while a
+// single transition (ex: a permanent change of offset) does not repeat.
+func groupICSTransitions(transitions []icsTransition, lastYear int) []icsTransitionGroup {
+	type pattern struct {
+		name       string
+		offsetFrom int
+		offsetTo   int
+		isDST      bool
+		month      time.Month
+		weekday    time.Weekday
+		nth        int
+		clock      string
+	}
+	patternOf := func(tr icsTransition) pattern {
+		local := tr.at.Add(time.Duration(tr.offsetFrom) * time.Second).UTC()
+		nth := (local.Day()-1)/7 + 1
+		if local.Day()+7 > daysIn(local.Year(), local.Month()) {
+			nth = -1
+		}
+		return pattern{tr.name, tr.offsetFrom, tr.offsetTo, tr.isDST, local.Month(), local.Weekday(), nth, local.Format("150405")}
+	}
+
+	groups := []icsTransitionGroup{}
+	open := map[pattern]int{}
+	for _, tr := range transitions {
+		p := patternOf(tr)
+		if i, ok := open[p]; ok {
+			last := groups[i].transitions[len(groups[i].transitions)-1]
+			if last.at.Year()+1 == tr.at.Year() {
+				groups[i].transitions = append(groups[i].transitions, tr)
+				continue
+			}
+		}
+		open[p] = len(groups)
+		groups = append(groups, icsTransitionGroup{transitions: []icsTransition{tr}})
+	}
+
+	for i, group := range groups {
+		first := group.transitions[0]
+		last := group.transitions[len(group.transitions)-1]
+		if len(group.transitions) == 1 {
+			continue
+		}
+		p := patternOf(first)
+		rule := RRule{
+			Freq:      RRuleYearly,
+			Interval:  1,
+			ByMonth:   []int{int(p.month)},
+			ByDay:     []RRuleWeekday{{Weekday: p.weekday, N: p.nth}},
+			WeekStart: time.Monday,
+		}
+		if last.at.Year() != lastYear {
+			rule.Until = last.at.UTC()
+		}
+		groups[i].rule = rule.String()
+	}
+	return groups
+}
+
+// formatICSOffset returns the UTC offset in seconds in the RFC 5545 form, for
+// example "-0500" or "+0530".
+func formatICSOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
@@ -0,0 +1,671 @@
+package timekit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidICS is returned (wrapped with more details) when iCalendar data
+// cannot be parsed.
+var ErrInvalidICS = errors.New("timekit: invalid iCalendar data")
+
+// icsProperty is a single content line of an iCalendar file, for example
+// `DTSTART;TZID=America/Toronto:20240101T090000`.
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icsComponent is a `BEGIN` and `END` block of an iCalendar file along with
+// its properties and nested components.
+type icsComponent struct {
+	name       string
+	properties []icsProperty
+	components []*icsComponent
+}
+
+// get returns the first property with the name.
+func (c *icsComponent) get(name string) (icsProperty, bool) {
+	for _, prop := range c.properties {
+		if prop.name == name {
+			return prop, true
+		}
+	}
+	return icsProperty{}, false
+}
+
+// ParseICS reads the `VEVENT` components of iCalendar data into a calendar,
+// which can then be expanded into ranges with the `TimeRanges` function. The
+// `TZID` of the dates/times is looked up in the time zone database and, if it
+// is not found there (ex: "Eastern Standard Time"), it is built from the
+// embedded `VTIMEZONE` component. Floating dates/times (without a `TZID` or
+// "Z" suffix) and all-day dates use the inputted location, or UTC if nil.
+func ParseICS(r io.Reader, loc *time.Location) (*ICSCalendar, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseICSComponents(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewICSCalendar("")
+	for _, calendar := range root.components {
+		if calendar.name != "VCALENDAR" {
+			continue
+		}
+		if prop, ok := calendar.get("PRODID"); ok {
+			c.ProductID = unescapeICSText(prop.value)
+		}
+
+		zones := map[string]*time.Location{}
+		for _, component := range calendar.components {
+			if component.name != "VTIMEZONE" {
+				continue
+			}
+			tzid, zone, err := parseICSTimeZone(component)
+			if err != nil {
+				return nil, err
+			}
+			zones[tzid] = zone
+		}
+
+		for _, component := range calendar.components {
+			if component.name != "VEVENT" {
+				continue
+			}
+			event, err := parseICSEvent(component, zones, loc)
+			if err != nil {
+				return nil, err
+			}
+			c.AddEvent(event)
+		}
+	}
+	return c, nil
+}
+
+// TimeRanges expands the events of the calendar, including the occurrences of
+// repeating events, into the ranges which overlap the `[start, end)` window.
+// The ranges are sorted by their start. Excluded dates are removed and
+// occurrences replaced by an event with a `RecurrenceID` use the range of the
+// replacing event.
+func (c *ICSCalendar) TimeRanges(start time.Time, end time.Time) []*TimeRange {
+	results := []*TimeRange{}
+	overlaps := func(tr *TimeRange) bool {
+		if tr.IsEmpty() {
+			return !tr.Start.Before(start) && tr.Start.Before(end)
+		}
+		return tr.Start.Before(end) && tr.End.After(start)
+	}
+
+	replaced := map[string][]time.Time{}
+	for _, event := range c.Events {
+		if event.RecurrenceID.IsZero() {
+			continue
+		}
+		replaced[event.UID] = append(replaced[event.UID], event.RecurrenceID)
+		if tr := (&TimeRange{Start: event.Start, End: event.End}); overlaps(tr) {
+			results = append(results, tr)
+		}
+	}
+
+	for _, event := range c.Events {
+		if !event.RecurrenceID.IsZero() {
+			continue
+		}
+		if event.RRule == nil && len(event.RDates) == 0 {
+			if tr := (&TimeRange{Start: event.Start, End: event.End}); overlaps(tr) {
+				results = append(results, tr)
+			}
+			continue
+		}
+
+		rs := NewRecurrenceSet(icsOccurrences(event))
+		rs.Exclude(event.ExDates...)
+		rs.Exclude(replaced[event.UID]...)
+		rs.Add(event.RDates...)
+
+		// Developers Note:
+		// Occurrences which start before the window can still overlap it, so
+		// we look back by the length of the event (plus a day for any
+		// daylight saving transition).
+		length := event.End.Sub(event.Start) + 24*time.Hour
+		for _, occurrence := range rs.Between(start.Add(-length), end) {
+			tr := &TimeRange{Start: occurrence.Time, End: icsOccurrenceEnd(event, occurrence.Time)}
+			if overlaps(tr) {
+				results = append(results, tr)
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Start.Equal(results[j].Start) {
+			return results[i].End.Before(results[j].End)
+		}
+		return results[i].Start.Before(results[j].Start)
+	})
+	return results
+}
+
+// icsOccurrences returns an iterator of the starts of a repeating event. The
+// start of the event is always the first occurrence, as per RFC 5545, even
+// when it does not match the rule.
+func icsOccurrences(event ICSEvent) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if !yield(event.Start) {
+			return
+		}
+		if event.RRule == nil {
+			return
+		}
+		count := 1
+		for dt := range event.RRule.All(event.Start) {
+			if dt.Equal(event.Start) {
+				continue
+			}
+			// Developers Note:
+			// The `COUNT` includes the start of the event, so we stop one
+			// early when the rule itself did not include the start.
+			if event.RRule.Count > 0 && count >= event.RRule.Count {
+				return
+			}
+			if !yield(dt) {
+				return
+			}
+			count++
+		}
+	}
+}
+
+// icsOccurrenceEnd returns the end of the occurrence, which keeps the same
+// local length as the event so, for example, an event from 9 AM to 10 AM ends
+// at 10 AM on every day across daylight saving transitions.
+func icsOccurrenceEnd(event ICSEvent, occurrence time.Time) time.Time {
+	loc := event.Start.Location()
+	length := wallClockOf(event.End.In(loc)).Sub(wallClockOf(event.Start))
+	wall := wallClockOf(occurrence.In(loc)).Add(length)
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+}
+
+// parseICSComponents unfolds the lines of the iCalendar data and parses them
+// into a tree of components under an unnamed root component.
+func parseICSComponents(data string) (*icsComponent, error) {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	lines := []string{}
+	for _, line := range strings.Split(data, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	root := &icsComponent{}
+	stack := []*icsComponent{root}
+	for _, line := range lines {
+		prop, err := parseICSProperty(line)
+		if err != nil {
+			return nil, err
+		}
+		current := stack[len(stack)-1]
+		switch prop.name {
+		case "BEGIN":
+			component := &icsComponent{name: strings.ToUpper(prop.value)}
+			current.components = append(current.components, component)
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 1 || current.name != strings.ToUpper(prop.value) {
+				return nil, fmt.Errorf("%w: unexpected END:%s", ErrInvalidICS, prop.value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			current.properties = append(current.properties, prop)
+		}
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("%w: missing END:%s", ErrInvalidICS, stack[len(stack)-1].name)
+	}
+	return root, nil
+}
+
+// parseICSProperty parses a single unfolded content line.
+func parseICSProperty(line string) (icsProperty, error) {
+	prop := icsProperty{params: map[string]string{}}
+
+	// Developers Note:
+	// Parameter values can be quoted and contain ":" and ";" characters, so
+	// we find the value separator by skipping over the quoted text.
+	quoted := false
+	split := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			split = i
+			break
+		}
+	}
+	if split == -1 {
+		return prop, fmt.Errorf("%w: malformed line %q", ErrInvalidICS, line)
+	}
+	prop.value = line[split+1:]
+
+	parts := splitICSParams(line[:split])
+	prop.name = strings.ToUpper(parts[0])
+	if prop.name == "" {
+		return prop, fmt.Errorf("%w: malformed line %q", ErrInvalidICS, line)
+	}
+	for _, part := range parts[1:] {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return prop, fmt.Errorf("%w: malformed parameter %q", ErrInvalidICS, part)
+		}
+		prop.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+// splitICSParams splits the name and parameters of a content line on the ";"
+// characters which are not quoted.
+func splitICSParams(s string) []string {
+	parts := []string{}
+	quoted := false
+	last := 0
+	for i, r := range s {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ';' && !quoted {
+			parts = append(parts, s[last:i])
+			last = i + 1
+		}
+	}
+	return append(parts, s[last:])
+}
+
+// unescapeICSText reverses the `escapeICSText` function.
+func unescapeICSText(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			sb.WriteByte('\n')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+// parseICSEvent converts a `VEVENT` component into an event.
+func parseICSEvent(component *icsComponent, zones map[string]*time.Location, loc *time.Location) (ICSEvent, error) {
+	event := ICSEvent{}
+	if prop, ok := component.get("UID"); ok {
+		event.UID = unescapeICSText(prop.value)
+	}
+	if prop, ok := component.get("SUMMARY"); ok {
+		event.Summary = unescapeICSText(prop.value)
+	}
+	if prop, ok := component.get("DESCRIPTION"); ok {
+		event.Description = unescapeICSText(prop.value)
+	}
+
+	prop, ok := component.get("DTSTART")
+	if !ok {
+		return event, fmt.Errorf("%w: event %q is missing DTSTART", ErrInvalidICS, event.UID)
+	}
+	starts, allDay, err := parseICSTimes(prop, zones, loc)
+	if err != nil {
+		return event, err
+	}
+	event.Start = starts[0]
+	event.AllDay = allDay
+
+	if prop, ok := component.get("DTEND"); ok {
+		ends, _, err := parseICSTimes(prop, zones, loc)
+		if err != nil {
+			return event, err
+		}
+		event.End = ends[0]
+	} else if prop, ok := component.get("DURATION"); ok {
+		days, duration, err := parseICSDuration(prop.value)
+		if err != nil {
+			return event, err
+		}
+		event.End = event.Start.AddDate(0, 0, days).Add(duration)
+	} else if allDay {
+		event.End = event.Start.AddDate(0, 0, 1)
+	} else {
+		event.End = event.Start
+	}
+	if event.End.Before(event.Start) {
+		return event, fmt.Errorf("%w: event %q ends before it starts", ErrInvalidICS, event.UID)
+	}
+
+	if prop, ok := component.get("RRULE"); ok {
+		if event.RRule, err = ParseRRule(prop.value); err != nil {
+			return event, err
+		}
+	}
+	if prop, ok := component.get("RECURRENCE-ID"); ok {
+		ids, _, err := parseICSTimes(prop, zones, loc)
+		if err != nil {
+			return event, err
+		}
+		event.RecurrenceID = ids[0]
+	}
+	for _, prop := range component.properties {
+		switch prop.name {
+		case "EXDATE":
+			dates, _, err := parseICSTimes(prop, zones, loc)
+			if err != nil {
+				return event, err
+			}
+			event.ExDates = append(event.ExDates, dates...)
+		case "RDATE":
+			dates, _, err := parseICSTimes(prop, zones, loc)
+			if err != nil {
+				return event, err
+			}
+			event.RDates = append(event.RDates, dates...)
+		}
+	}
+	return event, nil
+}
+
+// parseICSTimes parses the comma separated dates/times of the property. The
+// boolean is true if the values are dates without a time of day. For
+// `PERIOD` values only the start of the period is used.
+func parseICSTimes(prop icsProperty, zones map[string]*time.Location, loc *time.Location) ([]time.Time, bool, error) {
+	zone := loc
+	if tzid := strings.TrimPrefix(prop.params["TZID"], "/"); tzid != "" {
+		var err error
+		if zone, err = lookupICSTimeZone(tzid, zones); err != nil {
+			return nil, false, err
+		}
+	}
+
+	results := []time.Time{}
+	dateOnly := prop.params["VALUE"] == "DATE"
+	for _, value := range strings.Split(prop.value, ",") {
+		value, _, _ = strings.Cut(strings.TrimSpace(value), "/")
+		var dt time.Time
+		var err error
+		switch {
+		case dateOnly || len(value) == 8:
+			dateOnly = true
+			dt, err = time.ParseInLocation("20060102", value, loc)
+		case strings.HasSuffix(value, "Z"):
+			dt, err = time.Parse("20060102T150405Z", value)
+		default:
+			dt, err = time.ParseInLocation("20060102T150405", value, zone)
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: invalid %s %q", ErrInvalidICS, prop.name, value)
+		}
+		results = append(results, dt)
+	}
+	return results, dateOnly, nil
+}
+
+// lookupICSTimeZone returns the location of the `TZID`, preferring the time
+// zone database over the `VTIMEZONE` components of the file.
+func lookupICSTimeZone(tzid string, zones map[string]*time.Location) (*time.Location, error) {
+	if zone, err := time.LoadLocation(tzid); err == nil {
+		return zone, nil
+	}
+	if zone, ok := zones[tzid]; ok {
+		return zone, nil
+	}
+	return nil, fmt.Errorf("%w: unknown TZID %q", ErrInvalidICS, tzid)
+}
+
+// parseICSDuration parses an RFC 5545 duration (ex: "P1DT2H" or "-PT15M")
+// into the nominal days (which follow the local time of day) and the exact
+// time.
+func parseICSDuration(s string) (int, time.Duration, error) {
+	// Developers Note:
+	// RFC 5545 durations are ISO 8601 durations without years, months or
+	// fractions of a second.
+	d, err := ParseISO8601Duration(s)
+	if err != nil || d.Years != 0 || d.Months != 0 || d.Nanoseconds != 0 {
+		return 0, 0, fmt.Errorf("%w: invalid DURATION %q", ErrInvalidICS, s)
+	}
+	days, duration := d.Weeks*7+d.Days, d.clock()
+	if d.Negative {
+		return -days, -duration, nil
+	}
+	return days, duration, nil
+}
+
+// icsObservance is a `STANDARD` or `DAYLIGHT` component of a `VTIMEZONE`.
+type icsObservance struct {
+	name       string
+	isDST      bool
+	offsetFrom int
+	offsetTo   int
+	start      time.Time
+	rule       *RRule
+	rdates     []time.Time
+}
+
+// icsObservanceMaxYear is the last year we expand the transitions of a
+// `VTIMEZONE` into.
+const icsObservanceMaxYear = 2200
+
+// parseICSTimeZone converts a `VTIMEZONE` component into a location. Go can
+// only build locations with daylight saving time from the time zone database
+// format, so we expand the transitions of the observances and encode them in
+// that format.
+func parseICSTimeZone(component *icsComponent) (string, *time.Location, error) {
+	prop, ok := component.get("TZID")
+	if !ok || prop.value == "" {
+		return "", nil, fmt.Errorf("%w: VTIMEZONE is missing TZID", ErrInvalidICS)
+	}
+	tzid := strings.TrimPrefix(prop.value, "/")
+
+	observances := []icsObservance{}
+	for _, sub := range component.components {
+		if sub.name != "STANDARD" && sub.name != "DAYLIGHT" {
+			continue
+		}
+		o := icsObservance{isDST: sub.name == "DAYLIGHT"}
+		var err error
+		for _, prop := range sub.properties {
+			switch prop.name {
+			case "TZNAME":
+				o.name = prop.value
+			case "TZOFFSETFROM":
+				o.offsetFrom, err = parseICSOffset(prop.value)
+			case "TZOFFSETTO":
+				o.offsetTo, err = parseICSOffset(prop.value)
+			case "DTSTART":
+				o.start, err = time.Parse("20060102T150405", prop.value)
+			case "RRULE":
+				o.rule, err = ParseRRule(prop.value)
+			case "RDATE":
+				for _, value := range strings.Split(prop.value, ",") {
+					var dt time.Time
+					if dt, err = time.Parse("20060102T150405", value); err == nil {
+						o.rdates = append(o.rdates, dt)
+					}
+				}
+			}
+			if err != nil {
+				return "", nil, fmt.Errorf("%w: invalid %s in VTIMEZONE %q", ErrInvalidICS, prop.name, tzid)
+			}
+		}
+		if o.start.IsZero() {
+			return "", nil, fmt.Errorf("%w: VTIMEZONE %q observance is missing DTSTART", ErrInvalidICS, tzid)
+		}
+		if o.name == "" {
+			o.name = formatICSOffset(o.offsetTo)
+		}
+		observances = append(observances, o)
+	}
+	if len(observances) == 0 {
+		return "", nil, fmt.Errorf("%w: VTIMEZONE %q has no observances", ErrInvalidICS, tzid)
+	}
+
+	zone, err := time.LoadLocationFromTZData(tzid, encodeTZif(observances))
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: VTIMEZONE %q: %v", ErrInvalidICS, tzid, err)
+	}
+	return tzid, zone, nil
+}
+
+// parseICSOffset parses an RFC 5545 UTC offset (ex: "-0500") into seconds.
+func parseICSOffset(s string) (int, error) {
+	if len(s) != 5 && len(s) != 7 || (s[0] != '+' && s[0] != '-') {
+		return 0, ErrInvalidICS
+	}
+	hours, err1 := strconv.Atoi(s[1:3])
+	minutes, err2 := strconv.Atoi(s[3:5])
+	seconds := 0
+	var err3 error
+	if len(s) == 7 {
+		seconds, err3 = strconv.Atoi(s[5:7])
+	}
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, ErrInvalidICS
+	}
+	offset := hours*3600 + minutes*60 + seconds
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// encodeTZif returns the observances in the version 2 time zone database
+// (TZif) format which `time.LoadLocationFromTZData` reads.
+func encodeTZif(observances []icsObservance) []byte {
+	type transition struct {
+		at    int64
+		index int
+	}
+	type zoneType struct {
+		offset int
+		isDST  bool
+		name   string
+	}
+	types := []zoneType{}
+	typeIndex := func(zt zoneType) int {
+		for i, existing := range types {
+			if existing == zt {
+				return i
+			}
+		}
+		types = append(types, zt)
+		return len(types) - 1
+	}
+
+	// Developers Note:
+	// The first type is used for the times before the first transition, so
+	// we reserve it and fill it in once we know the earliest transition.
+	types = append(types, zoneType{})
+
+	transitions := []transition{}
+	for _, o := range observances {
+		index := typeIndex(zoneType{o.offsetTo, o.isDST, o.name})
+		walls := append([]time.Time{o.start}, o.rdates...)
+		if o.rule != nil {
+			// The observance start is a local time stored in UTC, so an
+			// `UNTIL` in UTC needs to be converted into local time too.
+			rule := *o.rule
+			if !rule.Until.IsZero() && !rule.untilFloating {
+				rule.Until = rule.Until.Add(time.Duration(o.offsetFrom) * time.Second)
+			}
+			for wall := range rule.All(o.start) {
+				if wall.Year() > icsObservanceMaxYear {
+					break
+				}
+				walls = append(walls, wall)
+			}
+		}
+		for _, wall := range walls {
+			transitions = append(transitions, transition{wall.Unix() - int64(o.offsetFrom), index})
+		}
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].at < transitions[j].at
+	})
+
+	earliest := observances[0]
+	for _, o := range observances {
+		if o.start.Before(earliest.start) {
+			earliest = o
+		}
+	}
+	types[0] = zoneType{earliest.offsetFrom, false, formatICSOffset(earliest.offsetFrom)}
+	for _, o := range observances {
+		if o.offsetTo == earliest.offsetFrom && !o.isDST {
+			types[0].name = o.name
+			break
+		}
+	}
+
+	var abbrevs bytes.Buffer
+	nameIndex := map[string]int{}
+	for _, zt := range types {
+		if _, ok := nameIndex[zt.name]; !ok {
+			nameIndex[zt.name] = abbrevs.Len()
+			abbrevs.WriteString(zt.name)
+			abbrevs.WriteByte(0)
+		}
+	}
+
+	var buf bytes.Buffer
+	header := func(timeCount int, typeCount int, charCount int) {
+		buf.WriteString("TZif2")
+		buf.Write(make([]byte, 15))
+		for _, n := range []int{0, 0, 0, timeCount, typeCount, charCount} {
+			binary.Write(&buf, binary.BigEndian, uint32(n))
+		}
+	}
+
+	// Developers Note:
+	// Version 2 files start with an empty version 1 section which readers
+	// skip over, followed by the 64-bit section holding our data. Version 1
+	// requires at least one type so we write a copy of the first one.
+	header(0, 1, 1)
+	binary.Write(&buf, binary.BigEndian, int32(types[0].offset))
+	buf.Write([]byte{0, 0, 0})
+
+	header(len(transitions), len(types), abbrevs.Len())
+	for _, tr := range transitions {
+		binary.Write(&buf, binary.BigEndian, tr.at)
+	}
+	for _, tr := range transitions {
+		buf.WriteByte(byte(tr.index))
+	}
+	for _, zt := range types {
+		binary.Write(&buf, binary.BigEndian, int32(zt.offset))
+		isDST := byte(0)
+		if zt.isDST {
+			isDST = 1
+		}
+		buf.Write([]byte{isDST, byte(nameIndex[zt.name])})
+	}
+	buf.Write(abbrevs.Bytes())
+	buf.WriteString("\n\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,199 @@
+package timekit
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testICSData uses a Microsoft style time zone name which is not part of the
+// time zone database, so the embedded VTIMEZONE must be used.
+var testICSData = strings.Join([]string{
+	"BEGIN:VCALENDAR",
+	"VERSION:2.0",
+	"PRODID:-//Example//Calendar//EN",
+	"BEGIN:VTIMEZONE",
+	"TZID:Eastern Standard Time",
+	"BEGIN:STANDARD",
+	"DTSTART:16011104T020000",
+	"RRULE:FREQ=YEARLY;BYDAY=1SU;BYMONTH=11",
+	"TZOFFSETFROM:-0400",
+	"TZOFFSETTO:-0500",
+	"END:STANDARD",
+	"BEGIN:DAYLIGHT",
+	"DTSTART:16010311T020000",
+	"RRULE:FREQ=YEARLY;BYDAY=2SU;BYMONTH=3",
+	"TZOFFSETFROM:-0500",
+	"TZOFFSETTO:-0400",
+	"END:DAYLIGHT",
+	"END:VTIMEZONE",
+	"BEGIN:VEVENT",
+	"UID:standup@example.com",
+	"SUMMARY:Standup\\, team A",
+	"DESCRIPTION:A very long description which goes past the seventy five octet",
+	"  limit and so it is folded",
+	"DTSTART;TZID=Eastern Standard Time:20240307T100000",
+	"DURATION:PT15M",
+	"RRULE:FREQ=DAILY;COUNT=5",
+	"EXDATE;TZID=Eastern Standard Time:20240309T100000",
+	"BEGIN:VALARM",
+	"ACTION:DISPLAY",
+	"TRIGGER:-PT5M",
+	"END:VALARM",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:standup@example.com",
+	"RECURRENCE-ID;TZID=Eastern Standard Time:20240310T100000",
+	"DTSTART;TZID=Eastern Standard Time:20240310T110000",
+	"DTEND;TZID=Eastern Standard Time:20240310T113000",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:holiday@example.com",
+	"DTSTART;VALUE=DATE:20240308",
+	"END:VEVENT",
+	"END:VCALENDAR",
+	"",
+}, "\r\n")
+
+func TestParseICS(t *testing.T) {
+	c, err := ParseICS(strings.NewReader(testICSData), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Events) != 3 {
+		t.Fatalf("Incorrect number of events, got %d but was expecting 3", len(c.Events))
+	}
+
+	event := c.Events[0]
+	if event.Summary != "Standup, team A" {
+		t.Errorf("Incorrect summary, got %q", event.Summary)
+	}
+	if event.Description != "A very long description which goes past the seventy five octet limit and so it is folded" {
+		t.Errorf("Incorrect description, got %q", event.Description)
+	}
+	if !event.Start.Equal(time.Date(2024, 3, 7, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect start, got %s", event.Start)
+	}
+	if !event.End.Equal(time.Date(2024, 3, 7, 15, 15, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect end, got %s", event.End)
+	}
+	if !c.Events[1].RecurrenceID.Equal(time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect recurrence id, got %s", c.Events[1].RecurrenceID)
+	}
+	if !c.Events[2].AllDay || !c.Events[2].End.Equal(time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect all day event, got %v", c.Events[2])
+	}
+}
+
+func TestICSCalendarTimeRanges(t *testing.T) {
+	c, err := ParseICS(strings.NewReader(testICSData), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Developers Note:
+	// Daylight saving time begins on March 10th 2024, so the standup moves
+	// from 15:00 UTC to 14:00 UTC.
+	actual := c.TimeRanges(time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC))
+	expected := []*TimeRange{
+		{Start: time.Date(2024, 3, 7, 15, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 7, 15, 15, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 3, 8, 15, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 8, 15, 15, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 3, 11, 14, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 11, 14, 15, 0, 0, time.UTC)},
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("Incorrect number of ranges, got %v but was expecting %v", actual, expected)
+	}
+	for i := range expected {
+		if !actual[i].Start.Equal(expected[i].Start) || !actual[i].End.Equal(expected[i].End) {
+			t.Errorf("Incorrect range %d, got %v to %v but was expecting %v to %v", i, actual[i].Start, actual[i].End, expected[i].Start, expected[i].End)
+		}
+	}
+
+	// An occurrence which starts before the window but overlaps it.
+	actual = c.TimeRanges(time.Date(2024, 3, 7, 15, 10, 0, 0, time.UTC), time.Date(2024, 3, 7, 16, 0, 0, 0, time.UTC))
+	if len(actual) != 1 || !actual[0].Start.Equal(time.Date(2024, 3, 7, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect overlapping ranges, got %v", actual)
+	}
+}
+
+func TestParseICSRoundTrip(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule, err := ParseRRule("FREQ=WEEKLY;COUNT=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := newTestICSCalendar()
+	original.AddEvent(ICSEvent{
+		UID:         "weekly@example.com",
+		Summary:     "Review; weekly",
+		Description: "First line\nSecond line",
+		Start:       time.Date(2024, 3, 4, 9, 0, 0, 0, loc),
+		End:         time.Date(2024, 3, 4, 10, 0, 0, 0, loc),
+		RRule:       rule,
+	})
+
+	c, err := ParseICS(strings.NewReader(original.String()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Events[0].Summary != "Review; weekly" || c.Events[0].Description != "First line\nSecond line" {
+		t.Errorf("Incorrect text, got %q and %q", c.Events[0].Summary, c.Events[0].Description)
+	}
+
+	var actual []time.Time
+	for _, tr := range c.TimeRanges(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		actual = append(actual, tr.Start.In(loc))
+	}
+	expected := []time.Time{
+		time.Date(2024, 3, 4, 9, 0, 0, 0, loc),
+		time.Date(2024, 3, 11, 9, 0, 0, 0, loc),
+		time.Date(2024, 3, 18, 9, 0, 0, 0, loc),
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Incorrect occurrences, got %v but was expecting %v", actual, expected)
+	}
+}
+
+func TestParseICSErrors(t *testing.T) {
+	cases := map[string]string{
+		"missing dtstart": "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"unknown tzid":    "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;TZID=Nowhere:20240101T000000\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"missing end":     "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20240101T000000Z\r\nEND:VCALENDAR\r\n",
+		"malformed line":  "BEGIN:VCALENDAR\r\nNOT A PROPERTY\r\nEND:VCALENDAR\r\n",
+		"bad duration":    "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20240101T000000Z\r\nDURATION:P1X\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseICS(strings.NewReader(data), nil); !errors.Is(err, ErrInvalidICS) {
+				t.Errorf("Incorrect error, got %v but was expecting %v", err, ErrInvalidICS)
+			}
+		})
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	cases := []struct {
+		value    string
+		days     int
+		duration time.Duration
+	}{
+		{"PT15M", 0, 15 * time.Minute},
+		{"P1DT2H", 1, 2 * time.Hour},
+		{"P2W", 14, 0},
+		{"-PT1H30M", 0, -90 * time.Minute},
+	}
+	for _, tc := range cases {
+		days, duration, err := parseICSDuration(tc.value)
+		if err != nil || days != tc.days || duration != tc.duration {
+			t.Errorf("Incorrect duration for %q, got %d days and %s (%v)", tc.value, days, duration, err)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package timekit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestICSCalendar() *ICSCalendar {
+	c := NewICSCalendar("-//bartmika//timekit//EN")
+	c.Now = func() time.Time {
+		return time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+	return c
+}
+
+func TestICSCalendarAddTimeRanges(t *testing.T) {
+	c := newTestICSCalendar()
+	ranges := DailyRangesBetweenTimes(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	c.AddTimeRanges(ranges, func(tr *TimeRange) string {
+		return tr.Start.Format("20060102") + "@example.com"
+	}, "Shift", "")
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//bartmika//timekit//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VEVENT",
+		"UID:20240101@example.com",
+		"DTSTAMP:20240101T120000Z",
+		"DTSTART:20240101T000000Z",
+		"DTEND:20240102T000000Z",
+		"SUMMARY:Shift",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if actual := c.String(); actual != expected {
+		t.Errorf("Incorrect calendar, got %q but was expecting %q", actual, expected)
+	}
+}
+
+func TestICSCalendarRecurrenceWithTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule, err := ParseRRule("FREQ=WEEKLY;BYDAY=MO,WE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newTestICSCalendar()
+	c.AddEvent(ICSEvent{
+		UID:         "standup@example.com",
+		Summary:     "Standup",
+		Description: "Room 4, bring notes",
+		Start:       time.Date(2024, 1, 8, 10, 0, 0, 0, loc),
+		End:         time.Date(2024, 1, 8, 10, 15, 0, 0, loc),
+		RRule:       rule,
+		ExDates:     []time.Time{time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC)},
+	})
+	actual := c.String()
+
+	for _, expected := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:America/Toronto\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20230312T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\nTZNAME:EDT\r\nRRULE:FREQ=YEARLY;BYDAY=2SU;BYMONTH=3\r\nEND:DAYLIGHT\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20231105T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\nTZNAME:EST\r\nRRULE:FREQ=YEARLY;BYDAY=1SU;BYMONTH=11\r\nEND:STANDARD\r\n",
+		"DTSTART;TZID=America/Toronto:20240108T100000\r\n",
+		"DTEND;TZID=America/Toronto:20240108T101500\r\n",
+		"DESCRIPTION:Room 4\\, bring notes\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE\r\n",
+		"EXDATE;TZID=America/Toronto:20240110T100000\r\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("Calendar is missing %q, got %q", expected, actual)
+		}
+	}
+}
+
+func TestICSCalendarUnnamedFixedZone(t *testing.T) {
+	loc := time.FixedZone("", 7200)
+	c := newTestICSCalendar()
+	c.AddEvent(ICSEvent{
+		UID:   "call@example.com",
+		Start: time.Date(2024, 1, 8, 10, 0, 0, 0, loc),
+		End:   time.Date(2024, 1, 8, 11, 0, 0, 0, loc),
+	})
+	actual := c.String()
+
+	for _, expected := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:UTC+0200\r\n",
+		"TZOFFSETFROM:+0200\r\nTZOFFSETTO:+0200\r\nTZNAME:UTC+0200\r\n",
+		"DTSTART;TZID=UTC+0200:20240108T100000\r\n",
+		"DTEND;TZID=UTC+0200:20240108T110000\r\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("Calendar is missing %q, got %q", expected, actual)
+		}
+	}
+	if strings.Contains(actual, "TZID:\r\n") || strings.Contains(actual, "TZID=:") {
+		t.Errorf("Calendar has an empty TZID, got %q", actual)
+	}
+
+	// The embedded time zone must be enough to read the calendar back.
+	parsed, err := ParseICS(strings.NewReader(actual), time.UTC)
+	if err != nil {
+		t.Fatalf("Failed parsing calendar: %v", err)
+	}
+	if start := parsed.Events[0].Start; !start.Equal(c.Events[0].Start) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", start, c.Events[0].Start)
+	}
+}
+
+func TestICSCalendarLocalTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := time.Local
+	time.Local = loc
+	defer func() {
+		time.Local = local
+	}()
+	t.Setenv("TZ", "America/Toronto")
+
+	////
+	//// Case 1: The local location is written with its name.
+	////
+
+	c := newTestICSCalendar()
+	c.AddEvent(ICSEvent{
+		UID:   "local@example.com",
+		Start: time.Date(2024, 1, 8, 10, 0, 0, 0, time.Local),
+		End:   time.Date(2024, 1, 8, 11, 0, 0, 0, time.Local),
+	})
+	actual := c.String()
+	for _, expected := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:America/Toronto\r\n",
+		"DTSTART;TZID=America/Toronto:20240108T100000\r\n",
+		"DTEND;TZID=America/Toronto:20240108T110000\r\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("Calendar is missing %q, got %q", expected, actual)
+		}
+	}
+
+	////
+	//// Case 2: A local location without a name is rejected.
+	////
+
+	t.Setenv("TZ", "Nowhere/Zone")
+	if _, err := c.WriteTo(&strings.Builder{}); !errors.Is(err, ErrInvalidICSEvent) {
+		t.Errorf("Incorrect result, got %v but was expecting %v", err, ErrInvalidICSEvent)
+	}
+}
+
+func TestICSCalendarRecurrenceUntil(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	floating, err := ParseRRule("FREQ=DAILY;UNTIL=20240110T100000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc, err := ParseRRule("FREQ=DAILY;UNTIL=20240110T150000Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newTestICSCalendar()
+	c.AddEvent(ICSEvent{
+		UID:   "zoned@example.com",
+		Start: time.Date(2024, 1, 8, 10, 0, 0, 0, loc),
+		End:   time.Date(2024, 1, 8, 11, 0, 0, 0, loc),
+		RRule: floating,
+	})
+	c.AddEvent(ICSEvent{
+		UID:    "allday@example.com",
+		Start:  time.Date(2024, 1, 8, 0, 0, 0, 0, loc),
+		End:    time.Date(2024, 1, 9, 0, 0, 0, 0, loc),
+		AllDay: true,
+		RRule:  utc,
+	})
+	actual := c.String()
+
+	////
+	//// Case 1: Zoned events have their UNTIL written in UTC.
+	////
+
+	if !strings.Contains(actual, "DTSTART;TZID=America/Toronto:20240108T100000\r\nDTEND;TZID=America/Toronto:20240108T110000\r\nRRULE:FREQ=DAILY;UNTIL=20240110T150000Z\r\n") {
+		t.Errorf("Calendar is missing the UTC until, got %q", actual)
+	}
+
+	////
+	//// Case 2: All day events have their UNTIL written as a date.
+	////
+
+	if !strings.Contains(actual, "RRULE:FREQ=DAILY;UNTIL=20240110\r\n") {
+		t.Errorf("Calendar is missing the date until, got %q", actual)
+	}
+}
+
+func TestICSCalendarAllDayEvent(t *testing.T) {
+	c := newTestICSCalendar()
+	c.AddEvent(ICSEvent{
+		UID:    "holiday@example.com",
+		Start:  time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		End:    time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC),
+		AllDay: true,
+	})
+	actual := c.String()
+	if !strings.Contains(actual, "DTSTART;VALUE=DATE:20240701\r\nDTEND;VALUE=DATE:20240702\r\n") {
+		t.Errorf("Incorrect all day event, got %q", actual)
+	}
+	if strings.Contains(actual, "VTIMEZONE") {
+		t.Errorf("All day events should not have a time zone, got %q", actual)
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("a", 62) + "éé"
+	expected := "DESCRIPTION:" + strings.Repeat("a", 62) + "\r\n éé\r\n"
+	if actual := foldICSLine(line); actual != expected {
+		t.Errorf("Incorrect folding, got %q but was expecting %q", actual, expected)
+	}
+	for _, folded := range strings.Split(foldICSLine(strings.Repeat("x", 200)), "\r\n") {
+		if len(folded) > 75 {
+			t.Errorf("Line is longer than 75 octets: %q", folded)
+		}
+	}
+}
+
+func TestEscapeICSText(t *testing.T) {
+	actual := escapeICSText("a\\b;c,d\ne")
+	expected := `a\\b\;c\,d\ne`
+	if actual != expected {
+		t.Errorf("Incorrect escaping, got %q but was expecting %q", actual, expected)
+	}
+}
+
+func TestICSCalendarInvalidEvent(t *testing.T) {
+	c := newTestICSCalendar()
+	c.AddEvent(ICSEvent{Start: time.Now(), End: time.Now()})
+	if _, err := c.WriteTo(&strings.Builder{}); !errors.Is(err, ErrInvalidICSEvent) {
+		t.Errorf("Incorrect error, got %v but was expecting %v", err, ErrInvalidICSEvent)
+	}
+	if c.String() != "" {
+		t.Errorf("Expected an empty string for an invalid calendar")
+	}
+}
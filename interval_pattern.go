@@ -9,99 +9,54 @@ import "time"
 // Sunday Jan 9th - 1:59 AM --> Sunday Jan 9th - 2:00 AM
 // Sunday Jan 9th - 11:59 PM --> Sunday Jan 10th - 12:00 AM
 func GetFutureDateByFiveMinuteIntervalPattern(dt time.Time) time.Time {
-	currMin := dt.Minute()
-	availMins := [...]int{0, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55}
-
-	var nextAvailH int = dt.Hour() + 1
-	var nextAvailMin int
-	for _, availMin := range availMins {
-		if availMin >= currMin {
-			nextAvailMin = availMin
-			nextAvailH = dt.Hour() // Undue the next available hour.
-			break
-		}
-	}
-	return time.Date(dt.Year(), dt.Month(), dt.Day(), nextAvailH, nextAvailMin, 0, 0, dt.Location())
+	return ceilToMinuteInterval(dt, 5*time.Minute)
 }
 
-// GetFutureDateByTenMinuteIntervalPattern returns the future date that conforms to the 5 minute interval pattern. For example:
+// GetFutureDateByTenMinuteIntervalPattern returns the future date that conforms to the 10 minute interval pattern. For example:
 // Sunday Jan 9th - 1:00 AM --> Sunday Jan 9th - 1:00 AM
 // Sunday Jan 9th - 1:01 AM --> Sunday Jan 9th - 1:10 AM
 // Sunday Jan 9th - 1:28 AM --> Sunday Jan 9th - 1:30 AM
 // Sunday Jan 9th - 1:59 AM --> Sunday Jan 9th - 2:00 AM
 // Sunday Jan 9th - 11:59 PM --> Sunday Jan 10th - 12:00 AM
 func GetFutureDateByTenMinuteIntervalPattern(dt time.Time) time.Time {
-	currMin := dt.Minute()
-	availMins := [...]int{0, 10, 20, 30, 40, 50}
-
-	var nextAvailH int = dt.Hour() + 1
-	var nextAvailMin int
-	for _, availMin := range availMins {
-		if availMin >= currMin {
-			nextAvailMin = availMin
-			nextAvailH = dt.Hour() // Undue the next available hour.
-			break
-		}
-	}
-	return time.Date(dt.Year(), dt.Month(), dt.Day(), nextAvailH, nextAvailMin, 0, 0, dt.Location())
+	return ceilToMinuteInterval(dt, 10*time.Minute)
 }
 
-// GetFutureDateByFiveteenMinuteIntervalPattern returns the future date that conforms to the 5 minute interval pattern. For example:
+// GetFutureDateByFiveteenMinuteIntervalPattern returns the future date that conforms to the 15 minute interval pattern. For example:
 // Sunday Jan 9th - 1:00 AM --> Sunday Jan 9th - 1:00 AM
 // Sunday Jan 9th - 1:01 AM --> Sunday Jan 9th - 1:15 AM
 // Sunday Jan 9th - 1:28 AM --> Sunday Jan 9th - 1:30 AM
 // Sunday Jan 9th - 1:59 AM --> Sunday Jan 9th - 2:00 AM
 // Sunday Jan 9th - 11:59 PM --> Sunday Jan 10th - 12:00 AM
 func GetFutureDateByFiveteenMinuteIntervalPattern(dt time.Time) time.Time {
-	currMin := dt.Minute()
-	availMins := [...]int{0, 15, 30, 45}
-
-	var nextAvailH int = dt.Hour() + 1
-	var nextAvailMin int
-	for _, availMin := range availMins {
-		if availMin >= currMin {
-			nextAvailMin = availMin
-			nextAvailH = dt.Hour() // Undue the next available hour.
-			break
-		}
-	}
-	return time.Date(dt.Year(), dt.Month(), dt.Day(), nextAvailH, nextAvailMin, 0, 0, dt.Location())
+	return ceilToMinuteInterval(dt, 15*time.Minute)
 }
 
-// GetFutureDateByThirtyMinuteIntervalPattern returns the future date that conforms to the 5 minute interval pattern. For example:
+// GetFutureDateByThirtyMinuteIntervalPattern returns the future date that conforms to the 30 minute interval pattern. For example:
 // Sunday Jan 9th - 1:00 AM --> Sunday Jan 9th - 1:00 AM
 // Sunday Jan 9th - 1:01 AM --> Sunday Jan 9th - 1:30 AM
 // Sunday Jan 9th - 1:28 AM --> Sunday Jan 9th - 1:30 AM
 // Sunday Jan 9th - 1:59 AM --> Sunday Jan 9th - 2:00 AM
 // Sunday Jan 9th - 11:59 PM --> Sunday Jan 10th - 12:00 AM
 func GetFutureDateByThirtyMinuteIntervalPattern(dt time.Time) time.Time {
-	currMin := dt.Minute()
-	availMins := [...]int{0, 30}
-
-	var nextAvailH int = dt.Hour() + 1
-	var nextAvailMin int
-	for _, availMin := range availMins {
-		if availMin >= currMin {
-			nextAvailMin = availMin
-			nextAvailH = dt.Hour() // Undue the next available hour.
-			break
-		}
-	}
-	return time.Date(dt.Year(), dt.Month(), dt.Day(), nextAvailH, nextAvailMin, 0, 0, dt.Location())
+	return ceilToMinuteInterval(dt, 30*time.Minute)
 }
 
-// GetFutureDateByOneHourIntervalPattern returns the future date that conforms to the 5 minute interval pattern. For example:
+// GetFutureDateByOneHourIntervalPattern returns the future date that conforms to the 1 hour interval pattern. For example:
 // Sunday Jan 9th - 1:00 AM --> Sunday Jan 9th - 1:00 AM
-// Sunday Jan 9th - 1:01 AM --> Sunday Jan 9th - 1:05 AM
-// Sunday Jan 9th - 1:28 AM --> Sunday Jan 9th - 1:30 AM
-// Sunday Jan 9th - 1:59 AM --> Sunday Jan 9th - 2:00 AM
+// Sunday Jan 9th - 1:00:30 AM --> Sunday Jan 9th - 1:00 AM
+// Sunday Jan 9th - 1:01 AM --> Sunday Jan 9th - 2:00 AM
+// Sunday Jan 9th - 1:28 AM --> Sunday Jan 9th - 2:00 AM
 // Sunday Jan 9th - 11:59 PM --> Sunday Jan 10th - 12:00 AM
 func GetFutureDateByOneHourIntervalPattern(dt time.Time) time.Time {
-	currHour := dt.Hour()
-	currMinute := dt.Minute()
+	return ceilToMinuteInterval(dt, time.Hour)
+}
 
-	if currMinute == 0 {
-		return dt
-	}
-	return time.Date(dt.Year(), dt.Month(), dt.Day(), currHour+1, 0, 0, 0, dt.Location())
+// ceilToMinuteInterval rounds the date/time up to the interval after clearing
+// the seconds and nanoseconds, so for example 1:00:30 AM stays at 1:00 AM with
+// every interval. The seconds are cleared on the instant (and not rebuilt with
+// `time.Date`) so a date/time in the repeated "fall back" hour keeps its
+// offset.
+func ceilToMinuteInterval(dt time.Time, interval time.Duration) time.Time {
+	return Ceil(Floor(dt, time.Minute), interval)
 }
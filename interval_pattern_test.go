@@ -206,4 +206,61 @@ func TestGetFutureDateByOneHourIntervalPattern(t *testing.T) {
 	if expected != actual {
 		t.Errorf("Incorrect date, got %v but was expecting %v", actual, expected)
 	}
+
+	////////
+
+	dt = time.Date(2022, 1, 9, 1, 0, 30, 5, loc)      // Sunday Jan 9th - 1:00:30 AM
+	expected = time.Date(2022, 1, 9, 1, 0, 0, 0, loc) // Sunday Jan 9th - 1:00 AM
+	actual = GetFutureDateByOneHourIntervalPattern(dt)
+	if expected != actual {
+		t.Errorf("Incorrect date, got %v but was expecting %v", actual, expected)
+	}
+}
+
+func TestGetFutureDateByIntervalPatternDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	est := func(hour int, min int, sec int) time.Time {
+		// The second copy of 1 AM on Sunday Nov 5th 2023 is 6 AM in UTC.
+		return time.Date(2023, 11, 5, hour+5, min, sec, 0, time.UTC).In(loc)
+	}
+
+	////
+	//// Case 1: The repeated hour keeps its offset.
+	////
+
+	dt := est(1, 30, 0)       // 1:30 AM EST
+	expected := est(1, 30, 0) // 1:30 AM EST
+	actual := GetFutureDateByFiveMinuteIntervalPattern(dt)
+	if !expected.Equal(actual) {
+		t.Errorf("Incorrect date, got %v but was expecting %v", actual, expected)
+	}
+
+	////
+	//// Case 2: Seconds are cleared without moving to the first copy.
+	////
+
+	dt = est(1, 31, 30)      // 1:31:30 AM EST
+	expected = est(1, 45, 0) // 1:45 AM EST
+	actual = GetFutureDateByFiveteenMinuteIntervalPattern(dt)
+	if !expected.Equal(actual) {
+		t.Errorf("Incorrect date, got %v but was expecting %v", actual, expected)
+	}
+
+	////
+	//// Case 3: Rounding up never goes back to the first copy.
+	////
+
+	dt = est(1, 40, 0)      // 1:40 AM EST
+	expected = est(2, 0, 0) // 2:00 AM EST
+	actual = GetFutureDateByOneHourIntervalPattern(dt)
+	if !expected.Equal(actual) {
+		t.Errorf("Incorrect date, got %v but was expecting %v", actual, expected)
+	}
+	actual = GetFutureDateByThirtyMinuteIntervalPattern(dt)
+	if !expected.Equal(actual) {
+		t.Errorf("Incorrect date, got %v but was expecting %v", actual, expected)
+	}
 }
@@ -0,0 +1,394 @@
+package timekit
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidISO8601 is returned (wrapped with more details) when an ISO 8601
+// duration, interval or repeating interval cannot be parsed.
+var ErrInvalidISO8601 = errors.New("timekit: invalid ISO 8601 value")
+
+// iso8601DurationDesignators are the designators of the duration components
+// in the order they must appear, the first four belong to the date part and
+// the last three belong to the time part (after the `T`).
+const iso8601DurationDesignators = "YMWDHMS"
+
+// ISO8601Duration represents an ISO 8601 duration like `P1Y2M10DT2H30M`.
+// Unlike `time.Duration` the years, months, weeks and days are kept as
+// calendar components, so "1 month" added to Jan 15th is Feb 15th no matter
+// how many days are in January. Every component is zero or positive, a
+// negative duration (ex: `-P1D`) sets the `Negative` field instead. Only the
+// seconds may have a fraction (ex: `PT0.5S`) which is kept in `Nanoseconds`.
+type ISO8601Duration struct {
+	Negative    bool
+	Years       int
+	Months      int
+	Weeks       int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+// ParseISO8601Duration converts an ISO 8601 duration string (ex:
+// "P1Y2M10DT2H30M", "P3W" or "PT0.5S") into an `ISO8601Duration`.
+func ParseISO8601Duration(s string) (ISO8601Duration, error) {
+	var d ISO8601Duration
+	invalid := fmt.Errorf("%w: invalid duration %q", ErrInvalidISO8601, s)
+
+	value := s
+	if strings.HasPrefix(value, "-") {
+		d.Negative = true
+	}
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+	if !strings.HasPrefix(value, "P") {
+		return d, invalid
+	}
+	value = value[1:]
+
+	last := -1
+	inTime := false
+	number := ""
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= '0' && c <= '9' || c == '.' || c == ',':
+			number += string(c)
+			continue
+		case c == 'T' && !inTime && number == "":
+			inTime = true
+			continue
+		}
+
+		// Developers Note:
+		// The designators must appear in order and the `M` is months before
+		// the `T` and minutes after it.
+		designators := iso8601DurationDesignators[:4]
+		offset := 0
+		if inTime {
+			designators, offset = iso8601DurationDesignators[4:], 4
+		}
+		pos := strings.IndexByte(designators, c)
+		if pos < 0 || pos+offset <= last || number == "" {
+			return d, invalid
+		}
+		pos += offset
+		last = pos
+
+		whole, fraction, hasFraction := strings.Cut(strings.Replace(number, ",", ".", 1), ".")
+		number = ""
+		n, err := strconv.Atoi(whole)
+		if err != nil || n < 0 {
+			return d, invalid
+		}
+		if hasFraction {
+			if iso8601DurationDesignators[pos] != 'S' || !inTime || fraction == "" || len(fraction) > 9 {
+				return d, invalid
+			}
+			nanos, err := strconv.Atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+			if err != nil {
+				return d, invalid
+			}
+			d.Nanoseconds = nanos
+		}
+		*d.component(pos) = n
+	}
+	if number != "" || last < 0 || (inTime && last < 4) {
+		return d, invalid
+	}
+	return d, nil
+}
+
+// component returns a pointer to the field of the component at the position
+// of the `iso8601DurationDesignators`.
+func (d *ISO8601Duration) component(pos int) *int {
+	return []*int{&d.Years, &d.Months, &d.Weeks, &d.Days, &d.Hours, &d.Minutes, &d.Seconds}[pos]
+}
+
+// IsZero returns true if every component of the duration is zero.
+func (d ISO8601Duration) IsZero() bool {
+	return d.Years == 0 && d.Months == 0 && d.Weeks == 0 && d.Days == 0 && d.clock() == 0
+}
+
+// String returns the duration in ISO 8601 format, for example
+// "P1Y2M10DT2H30M". Components which are zero are left out and the zero
+// duration is returned as "PT0S".
+func (d ISO8601Duration) String() string {
+	if d.IsZero() {
+		return "PT0S"
+	}
+	var sb strings.Builder
+	if d.Negative {
+		sb.WriteByte('-')
+	}
+	sb.WriteByte('P')
+	for pos := 0; pos < len(iso8601DurationDesignators); pos++ {
+		n := *d.component(pos)
+		if pos == 4 && d.clock() != 0 {
+			sb.WriteByte('T')
+		}
+		if pos == 6 && d.Nanoseconds != 0 {
+			fraction := strings.TrimRight(fmt.Sprintf("%09d", d.Nanoseconds), "0")
+			sb.WriteString(strconv.Itoa(n) + "." + fraction + "S")
+			continue
+		}
+		if n != 0 {
+			sb.WriteString(strconv.Itoa(n))
+			sb.WriteByte(iso8601DurationDesignators[pos])
+		}
+	}
+	return sb.String()
+}
+
+// AddTo returns the date/time moved by the duration. The years, months and
+// days are added first with `AddDate` so they follow the local time of day
+// (ex: "P1D" across a daylight saving time change is still the same time the
+// next day) and then the hours, minutes and seconds are added as an exact
+// amount of time.
+func (d ISO8601Duration) AddTo(t time.Time) time.Time {
+	sign := 1
+	if d.Negative {
+		sign = -1
+	}
+	t = t.AddDate(sign*d.Years, sign*d.Months, sign*(d.Weeks*7+d.Days))
+	return t.Add(time.Duration(sign) * d.clock())
+}
+
+// clock returns the exact amount of time of the hours, minutes and seconds.
+func (d ISO8601Duration) clock() time.Duration {
+	return time.Duration(d.Hours)*time.Hour +
+		time.Duration(d.Minutes)*time.Minute +
+		time.Duration(d.Seconds)*time.Second +
+		time.Duration(d.Nanoseconds)
+}
+
+// scaled returns the duration with every component multiplied by `n`.
+func (d ISO8601Duration) scaled(n int) ISO8601Duration {
+	nanos := d.Nanoseconds * n
+	return ISO8601Duration{
+		Negative:    d.Negative,
+		Years:       d.Years * n,
+		Months:      d.Months * n,
+		Weeks:       d.Weeks * n,
+		Days:        d.Days * n,
+		Hours:       d.Hours * n,
+		Minutes:     d.Minutes * n,
+		Seconds:     d.Seconds*n + nanos/int(time.Second),
+		Nanoseconds: nanos % int(time.Second),
+	}
+}
+
+// ParseISO8601Interval converts an ISO 8601 time interval into a time range.
+// The interval can be written as a start and end (ex:
+// "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z"), a start and duration (ex:
+// "2007-03-01T13:00:00Z/P1Y2M10DT2H30M") or a duration and end (ex:
+// "P1Y2M10DT2H30M/2008-05-11T15:30:00Z").
+func ParseISO8601Interval(s string) (*TimeRange, error) {
+	start, end, d, err := parseISO8601IntervalParts(s)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case end.IsZero():
+		end = d.AddTo(start)
+	case start.IsZero():
+		d.Negative = true
+		start = d.AddTo(end)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: interval %q ends before it starts", ErrInvalidISO8601, s)
+	}
+	return &TimeRange{Start: start, End: end}, nil
+}
+
+// ToISO8601IntervalString converts the time range into an ISO 8601 time
+// interval string with a start and end, for example
+// "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z".
+func ToISO8601IntervalString(tr *TimeRange) string {
+	return ToISO8601String(tr.Start) + "/" + ToISO8601String(tr.End)
+}
+
+// parseISO8601IntervalParts splits the interval into its start, end and
+// duration where exactly one of the start or end is the zero time if a
+// duration was used.
+func parseISO8601IntervalParts(s string) (time.Time, time.Time, ISO8601Duration, error) {
+	var start, end time.Time
+	var d ISO8601Duration
+	invalid := fmt.Errorf("%w: invalid interval %q", ErrInvalidISO8601, s)
+
+	first, second, ok := strings.Cut(s, "/")
+	if !ok || strings.HasPrefix(first, "P") && strings.HasPrefix(second, "P") {
+		return start, end, d, invalid
+	}
+	var err error
+	if strings.HasPrefix(first, "P") {
+		if d, err = ParseISO8601Duration(first); err != nil {
+			return start, end, d, err
+		}
+	} else if start, err = ParseISO8601String(first); err != nil {
+		return start, end, d, invalid
+	}
+	if strings.HasPrefix(second, "P") {
+		if d, err = ParseISO8601Duration(second); err != nil {
+			return start, end, d, err
+		}
+	} else if end, err = ParseISO8601String(second); err != nil {
+		return start, end, d, invalid
+	}
+	return start, end, d, nil
+}
+
+// ISO8601RepeatingInterval represents an ISO 8601 repeating interval like
+// `R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M`. Exactly two of the `Start`, `End`
+// and `Duration` fields are set:
+//
+//   - `Start` and `End` repeat the interval back to back with the exact
+//     amount of time between them.
+//   - `Start` and `Duration` repeat forward from the start.
+//   - `Duration` and `End` repeat backwards from the end, so the intervals
+//     are produced most recent first.
+//
+// A `Repetitions` of -1 means the interval repeats forever (written as `R/`).
+type ISO8601RepeatingInterval struct {
+	Repetitions int
+	Start       time.Time
+	End         time.Time
+	Duration    ISO8601Duration
+}
+
+// ParseISO8601RepeatingInterval converts an ISO 8601 repeating interval
+// string (ex: "R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M" or
+// "R/P1D/2024-01-01T00:00:00Z") into an `ISO8601RepeatingInterval`.
+func ParseISO8601RepeatingInterval(s string) (*ISO8601RepeatingInterval, error) {
+	invalid := fmt.Errorf("%w: invalid repeating interval %q", ErrInvalidISO8601, s)
+
+	repeat, interval, ok := strings.Cut(s, "/")
+	if !ok || !strings.HasPrefix(repeat, "R") {
+		return nil, invalid
+	}
+	ri := &ISO8601RepeatingInterval{Repetitions: -1}
+	if repeat != "R" {
+		n, err := strconv.Atoi(repeat[1:])
+		if err != nil || n < 0 {
+			return nil, invalid
+		}
+		ri.Repetitions = n
+	}
+
+	var err error
+	if ri.Start, ri.End, ri.Duration, err = parseISO8601IntervalParts(interval); err != nil {
+		return nil, err
+	}
+
+	// Developers Note:
+	// Intervals which do not move forward would repeat on the same instant.
+	if ri.Start.IsZero() || ri.End.IsZero() {
+		if ri.Duration.IsZero() || ri.Duration.Negative {
+			return nil, invalid
+		}
+	} else if !ri.End.After(ri.Start) {
+		return nil, invalid
+	}
+	return ri, nil
+}
+
+// String returns the repeating interval in ISO 8601 format, for example
+// "R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M".
+func (ri *ISO8601RepeatingInterval) String() string {
+	var sb strings.Builder
+	sb.WriteByte('R')
+	if ri.Repetitions >= 0 {
+		sb.WriteString(strconv.Itoa(ri.Repetitions))
+	}
+	sb.WriteByte('/')
+	switch {
+	case ri.End.IsZero():
+		sb.WriteString(ToISO8601String(ri.Start) + "/" + ri.Duration.String())
+	case ri.Start.IsZero():
+		sb.WriteString(ri.Duration.String() + "/" + ToISO8601String(ri.End))
+	default:
+		sb.WriteString(ToISO8601String(ri.Start) + "/" + ToISO8601String(ri.End))
+	}
+	return sb.String()
+}
+
+// All returns an iterator which lazily yields every interval. Please note
+// a repeating interval without a number of repetitions never ends, so make
+// sure to `break` out of the loop.
+func (ri *ISO8601RepeatingInterval) All() iter.Seq[*TimeRange] {
+	return func(yield func(*TimeRange) bool) {
+		for i := 0; ri.Repetitions < 0 || i < ri.Repetitions; i++ {
+			tr := ri.interval(i)
+			if tr.Start.Year() > rruleMaxYear || tr.End.Year() < 1 {
+				return
+			}
+			if !yield(tr) {
+				return
+			}
+		}
+	}
+}
+
+// Expand returns every interval of a repeating interval with a number of
+// repetitions. An empty slice is returned if it repeats forever, use `All`
+// or `Between` instead.
+func (ri *ISO8601RepeatingInterval) Expand() []*TimeRange {
+	results := []*TimeRange{}
+	if ri.Repetitions < 0 {
+		return results
+	}
+	for tr := range ri.All() {
+		results = append(results, tr)
+	}
+	return results
+}
+
+// Between returns the intervals which overlap the `[start, end)` range in
+// the order they repeat.
+func (ri *ISO8601RepeatingInterval) Between(start time.Time, end time.Time) []*TimeRange {
+	window := &TimeRange{Start: start, End: end}
+	backwards := ri.Start.IsZero()
+	results := []*TimeRange{}
+	for tr := range ri.All() {
+		if !backwards && !tr.Start.Before(end) || backwards && !tr.End.After(start) {
+			break
+		}
+		if tr.Overlaps(window) {
+			results = append(results, tr)
+		}
+	}
+	return results
+}
+
+// interval returns the nth (starting at zero) interval. Each interval is
+// computed from the original start or end instead of the previous interval
+// so month ends do not drift (ex: Jan 31st + 2 months is Mar 31st and not
+// Mar 28th).
+func (ri *ISO8601RepeatingInterval) interval(n int) *TimeRange {
+	switch {
+	case ri.End.IsZero():
+		return &TimeRange{
+			Start: ri.Duration.scaled(n).AddTo(ri.Start),
+			End:   ri.Duration.scaled(n + 1).AddTo(ri.Start),
+		}
+	case ri.Start.IsZero():
+		d := ri.Duration
+		d.Negative = true
+		return &TimeRange{
+			Start: d.scaled(n + 1).AddTo(ri.End),
+			End:   d.scaled(n).AddTo(ri.End),
+		}
+	default:
+		step := ri.End.Sub(ri.Start)
+		return &TimeRange{
+			Start: ri.Start.Add(time.Duration(n) * step),
+			End:   ri.Start.Add(time.Duration(n+1) * step),
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package timekit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected ISO8601Duration
+		str      string
+	}{
+		{"P1Y2M10DT2H30M", ISO8601Duration{Years: 1, Months: 2, Days: 10, Hours: 2, Minutes: 30}, "P1Y2M10DT2H30M"},
+		{"P3W", ISO8601Duration{Weeks: 3}, "P3W"},
+		{"PT1M", ISO8601Duration{Minutes: 1}, "PT1M"},
+		{"P1M", ISO8601Duration{Months: 1}, "P1M"},
+		{"PT0,25S", ISO8601Duration{Nanoseconds: 250000000}, "PT0.25S"},
+		{"-P1DT1.5S", ISO8601Duration{Negative: true, Days: 1, Seconds: 1, Nanoseconds: 500000000}, "-P1DT1.5S"},
+		{"P0D", ISO8601Duration{}, "PT0S"},
+	}
+	for _, tc := range cases {
+		actual, err := ParseISO8601Duration(tc.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.value, err)
+		}
+		if actual != tc.expected {
+			t.Errorf("Incorrect duration for %q, got %+v but was expecting %+v", tc.value, actual, tc.expected)
+		}
+		if s := actual.String(); s != tc.str {
+			t.Errorf("Incorrect string for %q, got %q but was expecting %q", tc.value, s, tc.str)
+		}
+	}
+
+	for _, value := range []string{"", "P", "PT", "1D", "P1DT", "PD", "P1H", "PT1D", "P1D2Y", "P1.5D", "PT1.S", "P1M1M", "P-1D"} {
+		if _, err := ParseISO8601Duration(value); !errors.Is(err, ErrInvalidISO8601) {
+			t.Errorf("Incorrect error for %q, got %v but was expecting %v", value, err, ErrInvalidISO8601)
+		}
+	}
+}
+
+func TestISO8601DurationAddTo(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	////
+	//// Case 1: Calendar components follow the calendar.
+	////
+
+	d, _ := ParseISO8601Duration("P1Y2M10DT2H30M")
+	actual := d.AddTo(time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC))
+	if expected := time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 2: One day across daylight saving time keeps the time of day.
+	////
+
+	d, _ = ParseISO8601Duration("P1D")
+	actual = d.AddTo(time.Date(2024, 3, 9, 12, 0, 0, 0, loc))
+	if expected := time.Date(2024, 3, 10, 12, 0, 0, 0, loc); !actual.Equal(expected) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 3: Twenty four hours across daylight saving time is exact.
+	////
+
+	d, _ = ParseISO8601Duration("PT24H")
+	actual = d.AddTo(time.Date(2024, 3, 9, 12, 0, 0, 0, loc))
+	if expected := time.Date(2024, 3, 10, 13, 0, 0, 0, loc); !actual.Equal(expected) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 4: Negative durations move backwards.
+	////
+
+	d, _ = ParseISO8601Duration("-P1MT1H")
+	actual = d.AddTo(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	if expected := time.Date(2024, 2, 15, 11, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestParseISO8601Interval(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected *TimeRange
+	}{
+		{"2007-03-01T13:00:00Z/2008-05-11T15:30:00Z", &TimeRange{Start: time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), End: time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC)}},
+		{"2007-03-01T13:00:00Z/P1Y2M10DT2H30M", &TimeRange{Start: time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), End: time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC)}},
+		{"P1Y2M10DT2H30M/2008-05-11T15:30:00Z", &TimeRange{Start: time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), End: time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC)}},
+		{"2007-03-01T13:00:00Z/P1M", &TimeRange{Start: time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), End: time.Date(2007, 4, 1, 13, 0, 0, 0, time.UTC)}},
+	}
+	for _, tc := range cases {
+		actual, err := ParseISO8601Interval(tc.value)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.value, err)
+		}
+		if !actual.Start.Equal(tc.expected.Start) || !actual.End.Equal(tc.expected.End) {
+			t.Errorf("Incorrect interval for %q, got %s/%s but was expecting %s/%s", tc.value, actual.Start, actual.End, tc.expected.Start, tc.expected.End)
+		}
+	}
+
+	for _, value := range []string{"2007-03-01T13:00:00Z", "P1D/P2D", "2008-05-11T15:30:00Z/2007-03-01T13:00:00Z", "2007-03-01T13:00:00Z/P1X", "garbage/P1D"} {
+		if _, err := ParseISO8601Interval(value); !errors.Is(err, ErrInvalidISO8601) {
+			t.Errorf("Incorrect error for %q, got %v but was expecting %v", value, err, ErrInvalidISO8601)
+		}
+	}
+
+	tr := &TimeRange{Start: time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), End: time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC)}
+	if actual, expected := ToISO8601IntervalString(tr), "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z"; actual != expected {
+		t.Errorf("Incorrect interval string, got %q but was expecting %q", actual, expected)
+	}
+}
+
+func TestParseISO8601RepeatingInterval(t *testing.T) {
+	////
+	//// Case 1: Start and duration with month ends.
+	////
+
+	ri, err := ParseISO8601RepeatingInterval("R3/2024-01-31T00:00:00Z/P1M")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := ri.String(); s != "R3/2024-01-31T00:00:00Z/P1M" {
+		t.Errorf("Incorrect string, got %q", s)
+	}
+	actual := ri.Expand()
+	expectedStarts := []time.Time{
+		time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if len(actual) != len(expectedStarts) {
+		t.Fatalf("Incorrect number of intervals, got %d but was expecting %d", len(actual), len(expectedStarts))
+	}
+	for i, dt := range expectedStarts {
+		if !actual[i].Start.Equal(dt) {
+			t.Errorf("Incorrect start of interval %d, got %s but was expecting %s", i, actual[i].Start, dt)
+		}
+		if i > 0 && !actual[i].Start.Equal(actual[i-1].End) {
+			t.Errorf("Interval %d does not start where the previous one ended", i)
+		}
+	}
+
+	////
+	//// Case 2: Duration and end repeat backwards forever.
+	////
+
+	ri, err = ParseISO8601RepeatingInterval("R/P1D/2024-01-10T00:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ri.Expand()) != 0 {
+		t.Error("Incorrect expand of an endless repeating interval, was expecting no intervals")
+	}
+	between := ri.Between(time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC))
+	if len(between) != 3 {
+		t.Fatalf("Incorrect number of intervals, got %d but was expecting 3", len(between))
+	}
+	if expected := time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC); !between[0].Start.Equal(expected) {
+		t.Errorf("Incorrect first interval, got %s but was expecting %s", between[0].Start, expected)
+	}
+	if expected := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC); !between[2].Start.Equal(expected) {
+		t.Errorf("Incorrect last interval, got %s but was expecting %s", between[2].Start, expected)
+	}
+
+	////
+	//// Case 3: Start and end repeat back to back.
+	////
+
+	ri, err = ParseISO8601RepeatingInterval("R2/2024-01-01T09:00:00Z/2024-01-01T09:30:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for tr := range ri.All() {
+		if tr.Duration() != 30*time.Minute {
+			t.Errorf("Incorrect interval length, got %s", tr.Duration())
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Incorrect number of intervals, got %d but was expecting 2", count)
+	}
+
+	for _, value := range []string{"5/2024-01-01T00:00:00Z/P1D", "Rx/2024-01-01T00:00:00Z/P1D", "R2/2024-01-01T00:00:00Z/PT0S", "R2/2024-01-01T00:00:00Z/2024-01-01T00:00:00Z", "R2/P1D"} {
+		if _, err := ParseISO8601RepeatingInterval(value); !errors.Is(err, ErrInvalidISO8601) {
+			t.Errorf("Incorrect error for %q, got %v but was expecting %v", value, err, ErrInvalidISO8601)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package timekit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period represents an amount of calendar time like "1 month and 3 days",
+// which a `time.Duration` cannot represent since months and days do not have
+// a fixed length. Each field can be negative, for example the period from
+// Mar 15th back to Feb 10th is -1 month and -5 days.
+type Period struct {
+	Years   int
+	Months  int
+	Days    int
+	Hours   int
+	Minutes int
+	Seconds int
+}
+
+// PeriodBetween returns the period from `a` to `b` in whole years, months and
+// days followed by the hours, minutes and seconds which are left over. The
+// result always takes you from `a` to `b` when added with `AddTo` (up to the
+// second), which follows `AddDate` so the end of month cases behave the same
+// way, for example the period from Jan 31st to Mar 1st is 30 days since
+// adding 1 month to Jan 31st overflows into March. If `b` is before `a` then
+// every field is zero or negative.
+func PeriodBetween(a time.Time, b time.Time) Period {
+	b = b.In(a.Location())
+	sign := 1
+	if b.Before(a) {
+		sign = -1
+	}
+	passed := func(dt time.Time) bool {
+		if sign > 0 {
+			return dt.After(b)
+		}
+		return dt.Before(b)
+	}
+
+	// Developers Note:
+	// We start with a close guess and then step until we are at the last
+	// month (and day) which does not go past `b`.
+	months := (b.Year()-a.Year())*12 + int(b.Month()-a.Month())
+	for months != 0 && passed(a.AddDate(0, months, 0)) {
+		months -= sign
+	}
+	for !passed(a.AddDate(0, months+sign, 0)) {
+		months += sign
+	}
+	days := int(b.Sub(a.AddDate(0, months, 0)) / (24 * time.Hour))
+	for days != 0 && passed(a.AddDate(0, months, days)) {
+		days -= sign
+	}
+	for !passed(a.AddDate(0, months, days+sign)) {
+		days += sign
+	}
+
+	seconds := int(b.Sub(a.AddDate(0, months, days)) / time.Second)
+	return Period{
+		Years:   months / 12,
+		Months:  months % 12,
+		Days:    days,
+		Hours:   seconds / 3600,
+		Minutes: seconds % 3600 / 60,
+		Seconds: seconds % 60,
+	}
+}
+
+// ParsePeriod converts an ISO 8601 duration string (ex: "P1Y2M10DT2H30M")
+// into a period. Weeks are converted into days and fractions of a second are
+// not supported.
+func ParsePeriod(s string) (Period, error) {
+	d, err := ParseISO8601Duration(s)
+	if err != nil {
+		return Period{}, err
+	}
+	if d.Nanoseconds != 0 {
+		return Period{}, fmt.Errorf("%w: period %q has a fraction of a second", ErrInvalidISO8601, s)
+	}
+	p := Period{
+		Years:   d.Years,
+		Months:  d.Months,
+		Days:    d.Weeks*7 + d.Days,
+		Hours:   d.Hours,
+		Minutes: d.Minutes,
+		Seconds: d.Seconds,
+	}
+	if d.Negative {
+		return p.Negate(), nil
+	}
+	return p, nil
+}
+
+// AddTo returns the date/time moved by the period. The years, months and days
+// are added with `AddDate` so they follow the local time of day and the hours,
+// minutes and seconds are then added as an exact amount of time.
+func (p Period) AddTo(t time.Time) time.Time {
+	t = t.AddDate(p.Years, p.Months, p.Days)
+	return t.Add(time.Duration(p.Hours)*time.Hour + time.Duration(p.Minutes)*time.Minute + time.Duration(p.Seconds)*time.Second)
+}
+
+// Negate returns the period with every field negated.
+func (p Period) Negate() Period {
+	return Period{
+		Years:   -p.Years,
+		Months:  -p.Months,
+		Days:    -p.Days,
+		Hours:   -p.Hours,
+		Minutes: -p.Minutes,
+		Seconds: -p.Seconds,
+	}
+}
+
+// Normalized returns the period with the months carried into years and the
+// seconds and minutes carried into hours, for example "P14M" becomes "P1Y2M"
+// and "PT90M" becomes "PT1H30M". Days are never carried into months and
+// hours are never carried into days since their lengths vary.
+func (p Period) Normalized() Period {
+	months := p.Years*12 + p.Months
+	seconds := p.Hours*3600 + p.Minutes*60 + p.Seconds
+	return Period{
+		Years:   months / 12,
+		Months:  months % 12,
+		Days:    p.Days,
+		Hours:   seconds / 3600,
+		Minutes: seconds % 3600 / 60,
+		Seconds: seconds % 60,
+	}
+}
+
+// IsZero returns true if every field of the period is zero.
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// Equal returns true if both periods are the same once normalized, for
+// example "P1Y" is equal to "P12M" but "P1M" is not equal to "P30D".
+func (p Period) Equal(other Period) bool {
+	return p.Normalized() == other.Normalized()
+}
+
+// Compare returns -1 if the period is shorter than the other period, 0 if
+// they are the same length and +1 if it is longer when both are added to the
+// `from` date/time. A date is needed since for example "P1M" is shorter than
+// "P30D" in February but longer in March.
+func (p Period) Compare(other Period, from time.Time) int {
+	return p.AddTo(from).Compare(other.AddTo(from))
+}
+
+// String returns the period in ISO 8601 format, for example "P1Y2M10DT2H30M".
+// Periods with only negative fields are written with a leading minus (ex:
+// "-P1M5D") and mixed periods have a minus on each negative field (ex:
+// "P1M-5D"). The zero period is returned as "PT0S".
+func (p Period) String() string {
+	if p.IsZero() {
+		return "PT0S"
+	}
+	var sb strings.Builder
+	if p.Years <= 0 && p.Months <= 0 && p.Days <= 0 && p.Hours <= 0 && p.Minutes <= 0 && p.Seconds <= 0 {
+		sb.WriteByte('-')
+		p = p.Negate()
+	}
+	sb.WriteByte('P')
+	write := func(n int, designator byte) {
+		if n != 0 {
+			sb.WriteString(strconv.Itoa(n))
+			sb.WriteByte(designator)
+		}
+	}
+	write(p.Years, 'Y')
+	write(p.Months, 'M')
+	write(p.Days, 'D')
+	if p.Hours != 0 || p.Minutes != 0 || p.Seconds != 0 {
+		sb.WriteByte('T')
+		write(p.Hours, 'H')
+		write(p.Minutes, 'M')
+		write(p.Seconds, 'S')
+	}
+	return sb.String()
+}
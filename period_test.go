@@ -0,0 +1,127 @@
+package timekit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPeriodBetween(t *testing.T) {
+	cases := []struct {
+		a        time.Time
+		b        time.Time
+		expected Period
+	}{
+		{time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 20, 6, 30, 15, 0, time.UTC), Period{Years: 1, Months: 2, Days: 5, Hours: 6, Minutes: 30, Seconds: 15}},
+		{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), Period{Days: 29}},
+		{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Period{Days: 30}},
+		{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Period{Months: 2}},
+		{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), Period{Months: 11, Days: 30}},
+		{time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC), time.Date(2024, 2, 10, 11, 0, 0, 0, time.UTC), Period{Months: -1, Days: -5, Hours: -1}},
+		{time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC), Period{}},
+	}
+	for _, tc := range cases {
+		actual := PeriodBetween(tc.a, tc.b)
+		if actual != tc.expected {
+			t.Errorf("Incorrect period from %s to %s, got %s but was expecting %s", tc.a, tc.b, actual, tc.expected)
+		}
+		if dt := actual.AddTo(tc.a); !dt.Equal(tc.b) {
+			t.Errorf("Incorrect period from %s to %s, adding %s gives %s", tc.a, tc.b, actual, dt)
+		}
+	}
+}
+
+func TestPeriodBetweenAgreesWithAddTo(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Developers Note:
+	// Every pair of these dates crosses month ends, leap days or daylight
+	// saving time changes in either direction.
+	dates := []time.Time{
+		time.Date(2023, 12, 31, 23, 30, 0, 0, loc),
+		time.Date(2024, 1, 31, 0, 0, 0, 0, loc),
+		time.Date(2024, 2, 29, 12, 0, 0, 0, loc),
+		time.Date(2024, 3, 10, 1, 30, 0, 0, loc),
+		time.Date(2024, 3, 10, 3, 30, 0, 0, loc),
+		time.Date(2024, 3, 31, 2, 15, 45, 0, loc),
+		time.Date(2024, 11, 3, 1, 30, 0, 0, loc),
+		time.Date(2025, 2, 28, 0, 0, 0, 0, loc),
+	}
+	for _, a := range dates {
+		for _, b := range dates {
+			p := PeriodBetween(a, b)
+			if dt := p.AddTo(a); !dt.Equal(b) {
+				t.Errorf("Incorrect period from %s to %s, adding %s gives %s", a, b, p, dt)
+			}
+		}
+	}
+}
+
+func TestPeriodNormalizedAndEqual(t *testing.T) {
+	p := Period{Months: 14, Days: 40, Minutes: 90, Seconds: 75}
+	expected := Period{Years: 1, Months: 2, Days: 40, Hours: 1, Minutes: 31, Seconds: 15}
+	if actual := p.Normalized(); actual != expected {
+		t.Errorf("Incorrect normalized period, got %s but was expecting %s", actual, expected)
+	}
+	if !p.Equal(expected) {
+		t.Error("Incorrect equal, was expecting the periods to be equal")
+	}
+	if (Period{Months: 1}).Equal(Period{Days: 30}) {
+		t.Error("Incorrect equal, one month is not equal to thirty days")
+	}
+	if actual := (Period{Years: 1, Months: -13}).Normalized(); actual != (Period{Months: -1}) {
+		t.Errorf("Incorrect normalized period, got %s but was expecting -P1M", actual)
+	}
+}
+
+func TestPeriodCompare(t *testing.T) {
+	month := Period{Months: 1}
+	days := Period{Days: 30}
+	if month.Compare(days, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) != -1 {
+		t.Error("Incorrect compare, one month in February is shorter than thirty days")
+	}
+	if month.Compare(days, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) != 1 {
+		t.Error("Incorrect compare, one month in March is longer than thirty days")
+	}
+	if month.Compare(days, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) != 0 {
+		t.Error("Incorrect compare, one month in April is thirty days")
+	}
+}
+
+func TestPeriodString(t *testing.T) {
+	cases := []struct {
+		period   Period
+		expected string
+	}{
+		{Period{Years: 1, Months: 2, Days: 10, Hours: 2, Minutes: 30}, "P1Y2M10DT2H30M"},
+		{Period{Seconds: 5}, "PT5S"},
+		{Period{}, "PT0S"},
+		{Period{Months: -1, Days: -5}, "-P1M5D"},
+		{Period{Months: 1, Days: -5}, "P1M-5D"},
+	}
+	for _, tc := range cases {
+		if actual := tc.period.String(); actual != tc.expected {
+			t.Errorf("Incorrect string, got %q but was expecting %q", actual, tc.expected)
+		}
+	}
+
+	p, err := ParsePeriod("-P1M5D")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != (Period{Months: -1, Days: -5}) {
+		t.Errorf("Incorrect parsed period, got %s", p)
+	}
+	if p.Negate() != (Period{Months: 1, Days: 5}) {
+		t.Errorf("Incorrect negated period, got %s", p.Negate())
+	}
+	if p, _ := ParsePeriod("P2W"); p != (Period{Days: 14}) {
+		t.Errorf("Incorrect parsed period, got %s", p)
+	}
+	if _, err := ParsePeriod("PT0.5S"); !errors.Is(err, ErrInvalidISO8601) {
+		t.Errorf("Incorrect error, got %v but was expecting %v", err, ErrInvalidISO8601)
+	}
+}
@@ -4,14 +4,16 @@ import (
 	"time"
 )
 
-// YearsRange returns an array of the year integer values between two dates. For example if one date is 2000 and the other is 2002, the output will be [2000,2001,2002].
+// YearsRange returns an array of the year integer values between two dates. For example if one date is 2000 and the other is 2002, the output will be [2000,2001,2002]. If the start date is after the end date then the years are returned in descending order, for example [2002,2001,2000].
 func YearsRange(start time.Time, end time.Time) []int {
 	var years []int
 
 	// Developers Note:
 	// We want to leverage our already unit tested code for the `range` functionality so we will use the `TimeStepper`
 	// to iterate through the datetime values and add them to an `results` array.
-	ts := NewTimeStepper(start, end, 1, 0, 0, 0, 0, 0)
+	// We step from January 1st so a start on Feb 29th does not roll over into
+	// March of the years which are not leap years.
+	ts := newDirectionalTimeStepper(firstDayOfYear(start), firstDayOfYear(end), 1, 0, 0, 0, 0, 0)
 	running := true
 	for running {
 		// Get the value we are on in the timestepper.
@@ -27,14 +29,17 @@ func YearsRange(start time.Time, end time.Time) []int {
 	return years
 }
 
-// MonthRange returns an array of the month integer values between two dates. For example if one date is January 2000 and the other is March 2000, the output will be [1,2,3].
+// MonthRange returns an array of the month integer values between two dates. For example if one date is January 2000 and the other is March 2000, the output will be [1,2,3]. If the start date is after the end date then the months are returned in descending order, for example [3,2,1].
 func MonthRange(start time.Time, end time.Time) []int {
 	var months []int
 
 	// Developers Note:
 	// We want to leverage our already unit tested code for the `range` functionality so we will use the `TimeStepper`
 	// to iterate through the datetime values and add them to an `results` array.
-	ts := NewTimeStepper(start, end, 0, 1, 0, 0, 0, 0)
+	// We step from the first day of the month so a start late in the month
+	// (ex: the 31st) does not roll over into the following month when the
+	// month we step onto is shorter, which would repeat or skip months.
+	ts := newDirectionalTimeStepper(firstDayOfMonth(start), firstDayOfMonth(end), 0, 1, 0, 0, 0, 0)
 	running := true
 	for running {
 		// Get the value we are on in the timestepper.
@@ -54,6 +59,17 @@ func MonthRange(start time.Time, end time.Time) []int {
 	return months
 }
 
+// firstDayOfYear returns midnight of January 1st of the year of the date in UTC.
+func firstDayOfYear(dt time.Time) time.Time {
+	return time.Date(dt.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// firstDayOfMonth returns midnight of the first day of the month of the date
+// in UTC.
+func firstDayOfMonth(dt time.Time) time.Time {
+	return time.Date(dt.Year(), dt.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
 // AppendIfMissing will only append the integer if it does not exist in the array.
 func appendIfMissing(slice []int, i int) []int {
 	for _, ele := range slice {
@@ -96,14 +112,14 @@ func WeeksRange(start time.Time, end time.Time) []int {
 	return weeks
 }
 
-// DaysRange returns an array of the day integer values between two dates. For example if one date is January 1st 2000 and the other is January 5th 2000, the output will be [1,2,3,4,5].
+// DaysRange returns an array of the day integer values between two dates. For example if one date is January 1st 2000 and the other is January 5th 2000, the output will be [1,2,3,4,5]. If the start date is after the end date then the days are returned in descending order, for example [5,4,3,2,1].
 func DaysRange(start time.Time, end time.Time) []int {
 	var days []int
 
 	// Developers Note:
 	// We want to leverage our already unit tested code for the `range` functionality so we will use the `TimeStepper`
 	// to iterate through the datetime values and add them to an `results` array.
-	ts := NewTimeStepper(start, end, 0, 0, 1, 0, 0, 0)
+	ts := newDirectionalTimeStepper(start, end, 0, 0, 1, 0, 0, 0)
 
 	// Get the value we are on in the timestepper.
 	v := ts.Get()
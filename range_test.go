@@ -104,3 +104,63 @@ func TestDaysRange(t *testing.T) {
 		t.Errorf("Incorrect year ranges, got %v but was expecting %v", actualDays, expectedDays)
 	}
 }
+
+func TestRangesInReverse(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	t1 := time.Date(2002, 3, 5, 1, 0, 0, 0, loc)
+	t2 := time.Date(2000, 1, 1, 1, 0, 0, 0, loc)
+
+	actualYears := YearsRange(t1, t2)
+	expectedYears := []int{2002, 2001, 2000}
+	if reflect.DeepEqual(actualYears, expectedYears) == false {
+		t.Errorf("Incorrect year ranges, got %v but was expecting %v", actualYears, expectedYears)
+	}
+
+	t1 = time.Date(2000, 3, 5, 1, 0, 0, 0, loc)
+	t2 = time.Date(2000, 1, 5, 1, 0, 0, 0, loc)
+	actualMonths := MonthRange(t1, t2)
+	expectedMonths := []int{3, 2, 1}
+	if reflect.DeepEqual(actualMonths, expectedMonths) == false {
+		t.Errorf("Incorrect month ranges, got %v but was expecting %v", actualMonths, expectedMonths)
+	}
+
+	// Starting late in the month must not repeat or skip the shorter months.
+	for _, day := range []int{29, 30, 31} {
+		t1 = time.Date(2000, 3, day, 1, 0, 0, 0, loc)
+		t2 = time.Date(2000, 1, 5, 1, 0, 0, 0, loc)
+		actualMonths = MonthRange(t1, t2)
+		if reflect.DeepEqual(actualMonths, expectedMonths) == false {
+			t.Errorf("Incorrect month ranges from March %d, got %v but was expecting %v", day, actualMonths, expectedMonths)
+		}
+
+		t1 = time.Date(2000, 1, day, 1, 0, 0, 0, loc)
+		t2 = time.Date(2000, 3, 5, 1, 0, 0, 0, loc)
+		actualMonths = MonthRange(t1, t2)
+		if expected := []int{1, 2, 3}; reflect.DeepEqual(actualMonths, expected) == false {
+			t.Errorf("Incorrect month ranges from January %d, got %v but was expecting %v", day, actualMonths, expected)
+		}
+	}
+
+	// Starting on a leap day must not roll over into March.
+	t1 = time.Date(2000, 2, 29, 1, 0, 0, 0, loc)
+	t2 = time.Date(1997, 2, 28, 1, 0, 0, 0, loc)
+	actualYears = YearsRange(t1, t2)
+	expectedYears = []int{2000, 1999, 1998, 1997}
+	if reflect.DeepEqual(actualYears, expectedYears) == false {
+		t.Errorf("Incorrect year ranges, got %v but was expecting %v", actualYears, expectedYears)
+	}
+	t2 = time.Date(1999, 3, 1, 1, 30, 0, 0, loc)
+	actualYears = YearsRange(t1, t2)
+	expectedYears = []int{2000, 1999}
+	if reflect.DeepEqual(actualYears, expectedYears) == false {
+		t.Errorf("Incorrect year ranges, got %v but was expecting %v", actualYears, expectedYears)
+	}
+
+	t1 = time.Date(2000, 3, 2, 1, 0, 0, 0, loc)
+	t2 = time.Date(2000, 2, 27, 1, 0, 0, 0, loc)
+	actualDays := DaysRange(t1, t2)
+	expectedDays := []int{2, 1, 29, 28, 27}
+	if reflect.DeepEqual(actualDays, expectedDays) == false {
+		t.Errorf("Incorrect day ranges, got %v but was expecting %v", actualDays, expectedDays)
+	}
+}
@@ -0,0 +1,175 @@
+package timekit
+
+import (
+	"iter"
+	"slices"
+	"time"
+)
+
+// OccurrenceKind represents how an occurrence of a `RecurrenceSet` came to be.
+type OccurrenceKind int
+
+const (
+	// OriginalOccurrence is an unchanged occurrence of the base schedule.
+	OriginalOccurrence OccurrenceKind = iota
+
+	// AddedOccurrence is a one-off date which is not part of the base schedule.
+	AddedOccurrence
+
+	// MovedOccurrence is an occurrence of the base schedule which was moved
+	// to a different date/time by an override.
+	MovedOccurrence
+)
+
+// occurrenceKindNames is a mapping of the occurrence kinds to their names.
+var occurrenceKindNames = map[OccurrenceKind]string{
+	OriginalOccurrence: "original",
+	AddedOccurrence:    "added",
+	MovedOccurrence:    "moved",
+}
+
+// String returns the name of the occurrence kind, for example "moved".
+func (k OccurrenceKind) String() string {
+	return occurrenceKindNames[k]
+}
+
+// Occurrence is a single date/time produced by expanding a `RecurrenceSet`.
+// The `Original` field holds the date/time of the base schedule which the
+// occurrence came from, for moved occurrences this is the date/time before
+// it was moved and for added occurrences it is the zero time.
+type Occurrence struct {
+	Time     time.Time
+	Original time.Time
+	Kind     OccurrenceKind
+}
+
+// RecurrenceSet combines a base schedule with exceptions, like the `EXDATE`
+// and `RDATE` properties of RFC 5545. The base schedule can be any of the
+// schedule iterators, for example `WeeklyBasedRecurringSchedule`, `RRule.All`
+// or `ScheduleSpec.All`. Excluded dates remove occurrences (including added
+// ones), added dates insert one-off occurrences and overrides move a single
+// occurrence of the base schedule, keyed by its original date/time.
+type RecurrenceSet struct {
+	base      iter.Seq[time.Time]
+	excluded  map[timeKey]bool
+	added     []time.Time
+	overrides map[timeKey]time.Time
+}
+
+// timeKey is used to look up dates/times by instant since `time.Time` values
+// with different locations are not equal with the `==` operator.
+type timeKey struct {
+	sec  int64
+	nsec int
+}
+
+// keyOf returns the key of the date/time's instant.
+func keyOf(dt time.Time) timeKey {
+	return timeKey{sec: dt.Unix(), nsec: dt.Nanosecond()}
+}
+
+// NewRecurrenceSet is a constructor of the `RecurrenceSet` struct using the
+// iterator as the base schedule. The base schedule can be nil if the set only
+// holds added dates.
+func NewRecurrenceSet(base iter.Seq[time.Time]) *RecurrenceSet {
+	return &RecurrenceSet{
+		base:      base,
+		excluded:  map[timeKey]bool{},
+		added:     []time.Time{},
+		overrides: map[timeKey]time.Time{},
+	}
+}
+
+// Exclude removes the dates/times from the set, for example a cancelled
+// occurrence of the base schedule.
+func (rs *RecurrenceSet) Exclude(dts ...time.Time) {
+	for _, dt := range dts {
+		rs.excluded[keyOf(dt)] = true
+	}
+}
+
+// Add inserts one-off dates/times into the set.
+func (rs *RecurrenceSet) Add(dts ...time.Time) {
+	rs.added = append(rs.added, dts...)
+}
+
+// Override moves the occurrence of the base schedule at the original
+// date/time to the replacement date/time, for example "this week's standup is
+// at 11 instead of 10". Overrides of dates which are not part of the base
+// schedule are ignored.
+func (rs *RecurrenceSet) Override(original time.Time, replacement time.Time) {
+	rs.overrides[keyOf(original)] = replacement
+}
+
+// Expand returns every occurrence of the set ordered by date/time. Please
+// note the base schedule must end (ex: a count or until date) or else this
+// function will never return, use `Between` for endless schedules.
+func (rs *RecurrenceSet) Expand() []Occurrence {
+	return rs.expand(func(time.Time) bool { return false }, func(time.Time) bool { return true })
+}
+
+// Between returns the occurrences of the set which fall inside the
+// `[start, end)` range ordered by date/time. Moved occurrences are included
+// based on their new date/time.
+func (rs *RecurrenceSet) Between(start time.Time, end time.Time) []Occurrence {
+	// Developers Note:
+	// An override can move an occurrence from after the end of the range into
+	// the range, so we keep reading the base schedule for as long as the
+	// earliest moving override could still land before the end.
+	var shift time.Duration
+	for key, replacement := range rs.overrides {
+		original := time.Unix(key.sec, int64(key.nsec))
+		if d := original.Sub(replacement); d > shift {
+			shift = d
+		}
+	}
+	cutoff := end.Add(shift)
+
+	return rs.expand(func(dt time.Time) bool {
+		return !dt.Before(cutoff)
+	}, func(dt time.Time) bool {
+		return !dt.Before(start) && dt.Before(end)
+	})
+}
+
+// expand reads the base schedule until the `stop` function returns true and
+// returns the occurrences which the `keep` function accepts ordered by
+// date/time.
+func (rs *RecurrenceSet) expand(stop func(time.Time) bool, keep func(time.Time) bool) []Occurrence {
+	results := []Occurrence{}
+	seen := map[timeKey]bool{}
+
+	if rs.base != nil {
+		for dt := range rs.base {
+			if stop(dt) {
+				break
+			}
+			key := keyOf(dt)
+			seen[key] = true
+			if rs.excluded[key] {
+				continue
+			}
+			occurrence := Occurrence{Time: dt, Original: dt, Kind: OriginalOccurrence}
+			if replacement, ok := rs.overrides[key]; ok {
+				occurrence = Occurrence{Time: replacement, Original: dt, Kind: MovedOccurrence}
+			}
+			if keep(occurrence.Time) {
+				results = append(results, occurrence)
+			}
+		}
+	}
+
+	for _, dt := range rs.added {
+		key := keyOf(dt)
+		if rs.excluded[key] || seen[key] || !keep(dt) {
+			continue
+		}
+		seen[key] = true
+		results = append(results, Occurrence{Time: dt, Kind: AddedOccurrence})
+	}
+
+	slices.SortStableFunc(results, func(a, b Occurrence) int {
+		return a.Time.Compare(b.Time)
+	})
+	return results
+}
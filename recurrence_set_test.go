@@ -0,0 +1,61 @@
+package timekit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceSetExpand(t *testing.T) {
+	// Daily standup at 10 AM on weekdays for one week.
+	startDateTime := time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC) // Monday
+	base := WeekdaysBetweenRange(startDateTime, startDateTime.AddDate(0, 0, 4), []int8{1, 2, 3, 4, 5})
+
+	rs := NewRecurrenceSet(base)
+	rs.Exclude(time.Date(2024, 1, 9, 10, 0, 0, 0, time.UTC))
+	rs.Add(time.Date(2024, 1, 13, 10, 0, 0, 0, time.UTC))
+	rs.Override(time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC))
+	rs.Override(time.Date(2024, 1, 11, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC))
+	rs.Add(time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC)) // Already part of the base schedule.
+
+	expected := []Occurrence{
+		{Time: time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC), Original: time.Date(2024, 1, 11, 10, 0, 0, 0, time.UTC), Kind: MovedOccurrence},
+		{Time: time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC), Original: time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC), Kind: OriginalOccurrence},
+		{Time: time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC), Original: time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC), Kind: MovedOccurrence},
+		{Time: time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC), Original: time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC), Kind: OriginalOccurrence},
+		{Time: time.Date(2024, 1, 13, 10, 0, 0, 0, time.UTC), Kind: AddedOccurrence},
+	}
+	actual := rs.Expand()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Incorrect occurrences, got %v but was expecting %v", actual, expected)
+	}
+}
+
+func TestRecurrenceSetBetween(t *testing.T) {
+	// An endless weekly rule which would never finish expanding.
+	rule, err := ParseRRule("FREQ=WEEKLY;BYDAY=MO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	startDateTime := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC) // Monday
+
+	rs := NewRecurrenceSet(rule.All(startDateTime))
+	rs.Exclude(time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC))
+	rs.Override(time.Date(2024, 2, 5, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 17, 10, 0, 0, 0, time.UTC))
+	rs.Add(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	expected := []Occurrence{
+		{Time: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), Original: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), Kind: OriginalOccurrence},
+		{Time: time.Date(2024, 1, 17, 10, 0, 0, 0, time.UTC), Original: time.Date(2024, 2, 5, 10, 0, 0, 0, time.UTC), Kind: MovedOccurrence},
+	}
+	actual := rs.Between(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC))
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Incorrect occurrences, got %v but was expecting %v", actual, expected)
+	}
+}
+
+func TestOccurrenceKindString(t *testing.T) {
+	if MovedOccurrence.String() != "moved" || AddedOccurrence.String() != "added" || OriginalOccurrence.String() != "original" {
+		t.Errorf("Incorrect occurrence kind names")
+	}
+}
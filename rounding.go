@@ -0,0 +1,179 @@
+package timekit
+
+import "time"
+
+// roundingConfig holds the settings of the `Ceil`, `Floor` and `Round`
+// functions.
+type roundingConfig struct {
+	loc    *time.Location
+	anchor *time.Time
+}
+
+// RoundingOption is a function which changes the settings of the `Ceil`,
+// `Floor` and `Round` functions.
+type RoundingOption func(*roundingConfig)
+
+// WithRoundingLocation returns an option which sets the location whose wall
+// clock the slots are aligned to, by default the location of the date/time
+// being rounded is used.
+func WithRoundingLocation(loc *time.Location) RoundingOption {
+	return func(cfg *roundingConfig) {
+		cfg.loc = loc
+	}
+}
+
+// WithRoundingAnchor returns an option which aligns the slots to the wall
+// clock of the anchor, for example an anchor at 08:10 with a 30 minute
+// interval gives slots at 08:10, 08:40, 09:10 and so on (as well as 07:40,
+// 07:10 and so on before it). For intervals shorter than a day only the time
+// of day of the anchor in the rounding location matters and not its date.
+func WithRoundingAnchor(anchor time.Time) RoundingOption {
+	return func(cfg *roundingConfig) {
+		cfg.anchor = &anchor
+	}
+}
+
+// Ceil returns the first slot of the interval at or after the date/time, for
+// example 9:07 AM is rounded up to 9:15 AM with a 15 minute interval. The
+// slots are counted on the local wall clock from midnight of every day (or
+// from the time of day of the anchor set with `WithRoundingAnchor`), so a 90
+// minute interval has slots at 00:00, 01:30, 03:00 and so on and a 7 minute
+// interval has slots at 23:48, 23:55 and then 00:00 of the next day.
+// Intervals of a day or longer are counted from January 1st of year 1. When
+// the clocks go back a slot can happen twice and both are used, and when the
+// clocks go forward a skipped slot is moved to the end of the gap. The result
+// has no seconds or nanoseconds left over beyond the slot and is in the
+// rounding location. The date/time is returned unchanged if the interval is
+// not greater than zero.
+func Ceil(t time.Time, interval time.Duration, opts ...RoundingOption) time.Time {
+	if interval <= 0 {
+		return t
+	}
+	cfg := newRoundingConfig(t, opts)
+
+	// Developers Note:
+	// We start from the slot at or before our wall clock since, when the
+	// clocks go back, the later instant of an earlier wall clock slot can
+	// still be after our time.
+	for wall := cfg.floorWall(t, interval); wall.Year() <= rruleMaxYear; wall = cfg.nextWall(wall, interval) {
+		for _, dt := range wallClockInstants(wall, cfg.loc) {
+			if !dt.Before(t) {
+				return dt
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// Floor returns the last slot of the interval at or before the date/time, for
+// example 9:07 AM is rounded down to 9:00 AM with a 15 minute interval. The
+// slots follow the same rules as the `Ceil` function.
+func Floor(t time.Time, interval time.Duration, opts ...RoundingOption) time.Time {
+	if interval <= 0 {
+		return t
+	}
+	cfg := newRoundingConfig(t, opts)
+	for wall := cfg.floorWall(t, interval); wall.Year() >= 1; wall = cfg.floorLocalWall(wall.Add(-time.Nanosecond), interval) {
+		instants := wallClockInstants(wall, cfg.loc)
+		for i := len(instants) - 1; i >= 0; i-- {
+			if !instants[i].After(t) {
+				return instants[i]
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// Round returns the nearest slot of the interval to the date/time, halfway
+// values are rounded up. For example 9:07 AM is rounded to 9:00 AM and 9:08
+// AM is rounded to 9:15 AM with a 15 minute interval. The slots follow the
+// same rules as the `Ceil` function.
+func Round(t time.Time, interval time.Duration, opts ...RoundingOption) time.Time {
+	if interval <= 0 {
+		return t
+	}
+	floor := Floor(t, interval, opts...)
+	ceil := Ceil(t, interval, opts...)
+	if t.Sub(floor) < ceil.Sub(t) {
+		return floor
+	}
+	return ceil
+}
+
+// newRoundingConfig returns the settings from the options.
+func newRoundingConfig(t time.Time, opts []RoundingOption) *roundingConfig {
+	cfg := &roundingConfig{loc: t.Location()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// floorWall returns the slot at or before the wall clock of the date/time as
+// a local time stored in UTC.
+func (cfg *roundingConfig) floorWall(t time.Time, interval time.Duration) time.Time {
+	return cfg.floorLocalWall(wallClockOf(t.In(cfg.loc)), interval)
+}
+
+// floorLocalWall returns the slot at or before the local time stored in UTC.
+func (cfg *roundingConfig) floorLocalWall(wall time.Time, interval time.Duration) time.Time {
+	if interval >= 24*time.Hour {
+		if cfg.anchor == nil {
+			return wall.Truncate(interval)
+		}
+
+		// Developers Note:
+		// We only use how far the anchor is past a slot of the default slots,
+		// which avoids overflowing a `time.Duration` with anchors far away.
+		anchor := wallClockOf(cfg.anchor.In(cfg.loc))
+		phase := anchor.Sub(anchor.Truncate(interval))
+		return wall.Add(-phase).Truncate(interval).Add(phase)
+	}
+	day := cfg.dayOfSlots(wall, interval)
+	return day.Add(wall.Sub(day) / interval * interval)
+}
+
+// nextWall returns the slot after the slot (a local time stored in UTC).
+func (cfg *roundingConfig) nextWall(wall time.Time, interval time.Duration) time.Time {
+	if interval >= 24*time.Hour {
+		return wall.Add(interval)
+	}
+
+	// Developers Note:
+	// The slots restart every day, so an interval which does not divide a day
+	// has a shorter last slot (ex: 23:55 to 00:00 with 7 minutes).
+	next := cfg.dayOfSlots(wall, interval).AddDate(0, 0, 1)
+	return earlierTime(wall.Add(interval), next)
+}
+
+// dayOfSlots returns the first slot of the day (as a local time stored in UTC)
+// whose slots the local time falls between, which is midnight shifted by the
+// time of day of the anchor.
+func (cfg *roundingConfig) dayOfSlots(wall time.Time, interval time.Duration) time.Time {
+	var phase time.Duration
+	if cfg.anchor != nil {
+		anchor := wallClockOf(cfg.anchor.In(cfg.loc))
+		phase = anchor.Sub(anchor.Truncate(24*time.Hour)) % interval
+	}
+	day := wall.Truncate(24 * time.Hour).Add(phase)
+	if wall.Before(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// wallClockInstants returns every instant (earliest first) which displays as
+// the local time (stored in UTC) in the location. That is two instants when
+// the clocks go back and the end of the gap when the local time is skipped
+// because the clocks go forward.
+func wallClockInstants(wall time.Time, loc *time.Location) []time.Time {
+	earlier, ok := resolveWallClock(wall, loc, SkipNonexistentTime, EarlierAmbiguousTime)
+	if !ok {
+		return []time.Time{resolveScheduledWallClock(wall, loc)}
+	}
+	later, _ := resolveWallClock(wall, loc, SkipNonexistentTime, LaterAmbiguousTime)
+	if later.Equal(earlier) {
+		return []time.Time{earlier}
+	}
+	return []time.Time{earlier, later}
+}
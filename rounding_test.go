@@ -0,0 +1,166 @@
+package timekit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCeilFloorRound(t *testing.T) {
+	anchor := time.Date(2000, 1, 1, 8, 10, 0, 0, time.UTC)
+	cases := []struct {
+		dt       time.Time
+		interval time.Duration
+		opts     []RoundingOption
+		floor    time.Time
+		ceil     time.Time
+		round    time.Time
+	}{
+		{
+			dt:       time.Date(2024, 1, 9, 9, 7, 0, 0, time.UTC),
+			interval: 15 * time.Minute,
+			floor:    time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 9, 9, 15, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 9, 9, 7, 30, 0, time.UTC),
+			interval: 15 * time.Minute,
+			floor:    time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 9, 9, 15, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 9, 9, 15, 0, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+			interval: 15 * time.Minute,
+			floor:    time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 9, 2, 59, 0, 0, time.UTC),
+			interval: 90 * time.Minute,
+			floor:    time.Date(2024, 1, 9, 1, 30, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 9, 3, 0, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 9, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 9, 9, 3, 12, 345, time.UTC),
+			interval: 7 * time.Minute,
+			floor:    time.Date(2024, 1, 9, 8, 59, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 9, 9, 6, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 9, 9, 6, 0, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 2, 0, 0, 30, 0, time.UTC),
+			interval: 7 * time.Minute,
+			floor:    time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 2, 0, 7, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 1, 23, 57, 0, 0, time.UTC),
+			interval: 7 * time.Minute,
+			floor:    time.Date(2024, 1, 1, 23, 55, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 1, 23, 55, 0, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 2, 0, 1, 0, 0, time.UTC),
+			interval: 7 * time.Minute,
+			opts:     []RoundingOption{WithRoundingAnchor(time.Date(2020, 5, 5, 0, 3, 0, 0, time.UTC))},
+			floor:    time.Date(2024, 1, 1, 23, 58, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 2, 0, 3, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 2, 0, 3, 0, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 9, 9, 3, 12, 600000000, time.UTC),
+			interval: time.Second,
+			floor:    time.Date(2024, 1, 9, 9, 3, 12, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 9, 9, 3, 13, 0, time.UTC),
+			round:    time.Date(2024, 1, 9, 9, 3, 13, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+			interval: 30 * time.Minute,
+			opts:     []RoundingOption{WithRoundingAnchor(anchor)},
+			floor:    time.Date(2024, 1, 9, 8, 40, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 9, 9, 10, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 9, 9, 10, 0, 0, time.UTC),
+		},
+		{
+			dt:       time.Date(2024, 1, 9, 7, 0, 0, 0, time.UTC),
+			interval: 30 * time.Minute,
+			opts:     []RoundingOption{WithRoundingAnchor(anchor)},
+			floor:    time.Date(2024, 1, 9, 6, 40, 0, 0, time.UTC),
+			ceil:     time.Date(2024, 1, 9, 7, 10, 0, 0, time.UTC),
+			round:    time.Date(2024, 1, 9, 7, 10, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range cases {
+		if actual := Floor(tc.dt, tc.interval, tc.opts...); !actual.Equal(tc.floor) {
+			t.Errorf("Incorrect floor of %s to %s, got %s but was expecting %s", tc.dt, tc.interval, actual, tc.floor)
+		}
+		if actual := Ceil(tc.dt, tc.interval, tc.opts...); !actual.Equal(tc.ceil) {
+			t.Errorf("Incorrect ceil of %s to %s, got %s but was expecting %s", tc.dt, tc.interval, actual, tc.ceil)
+		}
+		if actual := Round(tc.dt, tc.interval, tc.opts...); !actual.Equal(tc.round) {
+			t.Errorf("Incorrect round of %s to %s, got %s but was expecting %s", tc.dt, tc.interval, actual, tc.round)
+		}
+	}
+
+	dt := time.Date(2024, 1, 9, 9, 7, 0, 0, time.UTC)
+	if actual := Ceil(dt, 0); actual != dt {
+		t.Errorf("Incorrect ceil with a zero interval, got %s", actual)
+	}
+}
+
+func TestCeilFloorRoundLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	////
+	//// Case 1: Slots follow the wall clock of the rounding location.
+	////
+
+	dt := time.Date(2024, 6, 1, 16, 20, 0, 0, time.UTC) // 12:20 PM EDT
+	actual := Floor(dt, 24*time.Hour, WithRoundingLocation(loc))
+	if expected := time.Date(2024, 6, 1, 0, 0, 0, 0, loc); !actual.Equal(expected) || actual.Location() != loc {
+		t.Errorf("Incorrect floor, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 2: Daily slots stay on local midnight across daylight saving time.
+	////
+
+	actual = Ceil(time.Date(2024, 3, 10, 12, 0, 0, 0, loc), 24*time.Hour)
+	if expected := time.Date(2024, 3, 11, 0, 0, 0, 0, loc); !actual.Equal(expected) {
+		t.Errorf("Incorrect ceil, got %s but was expecting %s", actual, expected)
+	}
+	actual = Floor(time.Date(2024, 3, 10, 12, 0, 0, 0, loc), 24*time.Hour)
+	if expected := time.Date(2024, 3, 10, 0, 0, 0, 0, loc); !actual.Equal(expected) {
+		t.Errorf("Incorrect floor, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 3: The skipped 2:30 AM slot moves to the end of the gap.
+	////
+
+	actual = Ceil(time.Date(2024, 3, 10, 1, 50, 0, 0, loc), 30*time.Minute)
+	if expected := time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect ceil in the gap, got %s but was expecting %s", actual, expected)
+	}
+
+	////
+	//// Case 4: The repeated 1:30 AM slot is used twice.
+	////
+
+	second := time.Date(2024, 11, 3, 6, 20, 0, 0, time.UTC) // 1:20 AM EST
+	if actual := Ceil(second, 30*time.Minute); !actual.Equal(time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect ceil in the repeated hour, got %s", actual)
+	}
+	if actual := Floor(second, 30*time.Minute); !actual.Equal(time.Date(2024, 11, 3, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect floor in the repeated hour, got %s", actual)
+	}
+}
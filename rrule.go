@@ -0,0 +1,740 @@
+package timekit
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRRule is returned (wrapped with more details) when a recurrence
+// rule string does not conform to the RFC 5545 `RRULE` syntax.
+var ErrInvalidRRule = errors.New("timekit: invalid recurrence rule")
+
+// RRuleFrequency represents the `FREQ` rule part of an RFC 5545 recurrence
+// rule which is the type of period the rule repeats on.
+type RRuleFrequency int
+
+// The frequencies supported by the `FREQ` rule part, from shortest to longest.
+const (
+	RRuleSecondly RRuleFrequency = iota
+	RRuleMinutely
+	RRuleHourly
+	RRuleDaily
+	RRuleWeekly
+	RRuleMonthly
+	RRuleYearly
+)
+
+// rruleFrequencyNames is a mapping of the frequencies to their RFC 5545 names.
+var rruleFrequencyNames = map[RRuleFrequency]string{
+	RRuleSecondly: "SECONDLY",
+	RRuleMinutely: "MINUTELY",
+	RRuleHourly:   "HOURLY",
+	RRuleDaily:    "DAILY",
+	RRuleWeekly:   "WEEKLY",
+	RRuleMonthly:  "MONTHLY",
+	RRuleYearly:   "YEARLY",
+}
+
+// String returns the RFC 5545 name of the frequency, for example "WEEKLY".
+func (f RRuleFrequency) String() string {
+	return rruleFrequencyNames[f]
+}
+
+// rruleWeekdayNames is a mapping of the weekdays to their RFC 5545 names.
+var rruleWeekdayNames = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// RRuleWeekday represents a single `BYDAY` value which is a weekday with an
+// optional ordinal. For example `2TU` (second Tuesday) has an `N` of 2 and
+// `-1FR` (last Friday) has an `N` of -1. An `N` of zero means every weekday of
+// that kind in the period.
+type RRuleWeekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// String returns the RFC 5545 form of the weekday, for example "-1FR".
+func (wd RRuleWeekday) String() string {
+	if wd.N == 0 {
+		return rruleWeekdayNames[wd.Weekday]
+	}
+	return strconv.Itoa(wd.N) + rruleWeekdayNames[wd.Weekday]
+}
+
+// RRule represents an RFC 5545 recurrence rule, for example the rule
+// `FREQ=MONTHLY;BYDAY=2TU;COUNT=10` is the second Tuesday of the month for ten
+// months. Rules do not hold their starting date/time (the `DTSTART`), instead
+// it is passed in when expanding the rule so the same rule can be reused. The
+// location of the starting date/time is used for every occurrence so the time
+// of day is kept across daylight saving transitions.
+//
+// Please note that when you create the struct yourself the `WeekStart` zero
+// value is Sunday, while `ParseRRule` defaults to Monday as per RFC 5545.
+type RRule struct {
+	Freq       RRuleFrequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	BySecond   []int
+	ByMinute   []int
+	ByHour     []int
+	ByDay      []RRuleWeekday
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByMonth    []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+
+	// untilFloating is true when the `UNTIL` was written without a UTC
+	// designator, in which case it is a local time in the location of the
+	// starting date/time. untilDateOnly is true when it was written as a date.
+	untilFloating bool
+	untilDateOnly bool
+}
+
+// ParseRRule converts an RFC 5545 recurrence rule string (ex:
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE") into an `RRule`. The "RRULE:" prefix
+// is optional. An `UNTIL` without the "Z" suffix is treated as a local time in
+// the location of the starting date/time when the rule is expanded.
+func ParseRRule(s string) (*RRule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRRule)
+	}
+
+	r := &RRule{
+		Interval:  1,
+		WeekStart: time.Monday,
+	}
+	hasFreq := false
+	for _, part := range strings.Split(s, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq, err = parseRRuleFrequency(value)
+			hasFreq = true
+		case "INTERVAL":
+			r.Interval, err = parseRRuleInt(value, 1, 1<<31-1)
+		case "COUNT":
+			r.Count, err = parseRRuleInt(value, 1, 1<<31-1)
+		case "UNTIL":
+			err = r.parseUntil(value)
+		case "BYSECOND":
+			r.BySecond, err = parseRRuleInts(value, 0, 59, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseRRuleInts(value, 0, 59, false)
+		case "BYHOUR":
+			r.ByHour, err = parseRRuleInts(value, 0, 23, false)
+		case "BYDAY":
+			r.ByDay, err = parseRRuleWeekdays(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseRRuleInts(value, 1, 31, true)
+		case "BYYEARDAY":
+			r.ByYearDay, err = parseRRuleInts(value, 1, 366, true)
+		case "BYWEEKNO":
+			r.ByWeekNo, err = parseRRuleInts(value, 1, 53, true)
+		case "BYMONTH":
+			r.ByMonth, err = parseRRuleInts(value, 1, 12, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseRRuleInts(value, 1, 366, true)
+		case "WKST":
+			var wd RRuleWeekday
+			wd, err = parseRRuleWeekday(value)
+			if err == nil && wd.N != 0 {
+				err = fmt.Errorf("%w: WKST cannot have an ordinal", ErrInvalidRRule)
+			}
+			r.WeekStart = wd.Weekday
+		default:
+			err = fmt.Errorf("%w: unsupported part %q", ErrInvalidRRule, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !hasFreq {
+		return nil, fmt.Errorf("%w: missing FREQ", ErrInvalidRRule)
+	}
+	if r.Count != 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot both be set", ErrInvalidRRule)
+	}
+	return r, nil
+}
+
+// String returns the RFC 5545 form of the rule without the "RRULE:" prefix.
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		switch {
+		case r.untilDateOnly:
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		case r.untilFloating:
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405"))
+		default:
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	appendInts := func(name string, values []int) {
+		if len(values) == 0 {
+			return
+		}
+		strs := make([]string, len(values))
+		for i, v := range values {
+			strs[i] = strconv.Itoa(v)
+		}
+		parts = append(parts, name+"="+strings.Join(strs, ","))
+	}
+	appendInts("BYSECOND", r.BySecond)
+	appendInts("BYMINUTE", r.ByMinute)
+	appendInts("BYHOUR", r.ByHour)
+	if len(r.ByDay) > 0 {
+		strs := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			strs[i] = wd.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(strs, ","))
+	}
+	appendInts("BYMONTHDAY", r.ByMonthDay)
+	appendInts("BYYEARDAY", r.ByYearDay)
+	appendInts("BYWEEKNO", r.ByWeekNo)
+	appendInts("BYMONTH", r.ByMonth)
+	appendInts("BYSETPOS", r.BySetPos)
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+rruleWeekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// All returns an iterator which lazily yields every occurrence of the rule
+// starting from the `dtstart` date/time. Rules without a `COUNT` or `UNTIL`
+// never end, so make sure to `break` out of the loop. Please note the
+// `dtstart` is only yielded if it matches the rule.
+func (r *RRule) All(dtstart time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		r.expand(dtstart, yield)
+	}
+}
+
+// Occurrences returns an iterator which lazily yields the occurrences of the
+// rule which fall inside the half-open `[start, end)` range.
+func (r *RRule) Occurrences(dtstart time.Time, start time.Time, end time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for dt := range r.All(dtstart) {
+			if !dt.Before(end) {
+				return
+			}
+			if !dt.Before(start) && !yield(dt) {
+				return
+			}
+		}
+	}
+}
+
+// Between returns the occurrences of the rule which fall inside the half-open
+// `[start, end)` range.
+func (r *RRule) Between(dtstart time.Time, start time.Time, end time.Time) []time.Time {
+	return collectTimes(r.Occurrences(dtstart, start, end))
+}
+
+// rruleMaxYear is the last year we will expand a rule into. This protects us
+// from looping forever on rules which never match (ex: February 30th).
+const rruleMaxYear = 9999
+
+// expand yields the occurrences of the rule in order until the rule ends or
+// the `yield` function returns false.
+func (r *RRule) expand(dtstart time.Time, yield func(time.Time) bool) {
+	loc := dtstart.Location()
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	// Developers Note:
+	// All our calendar arithmetic happens on the local date and time of day
+	// stored in UTC (which has no daylight saving). Only when an occurrence is
+	// yielded do we convert it back into the location of the `dtstart`.
+	wallStart := wallClockOf(dtstart)
+	until, hasUntil := r.untilIn(loc)
+	x := r.withDefaults(wallStart)
+
+	count := 0
+	emit := func(dt time.Time) bool {
+		if hasUntil && dt.After(until) {
+			return false
+		}
+		if !yield(dt) {
+			return false
+		}
+		count++
+		return r.Count <= 0 || count < r.Count
+	}
+
+	if x.Freq <= RRuleHourly {
+		x.expandSubDaily(dtstart, interval, emit)
+		return
+	}
+	for period := x.firstPeriod(wallStart); period.Year() <= rruleMaxYear; period = x.nextPeriod(period, interval) {
+		for _, wall := range x.periodCandidates(period, wallStart) {
+			if wall.Before(wallStart) {
+				continue
+			}
+			dt, _ := resolveWallClock(wall, loc, ShiftForwardNonexistentTime, EarlierAmbiguousTime)
+			if !emit(dt) {
+				return
+			}
+		}
+	}
+}
+
+// expandSubDaily passes the occurrences of the hourly, minutely and secondly
+// rules to the `emit` function until it returns false.
+func (r *RRule) expandSubDaily(dtstart time.Time, interval int, emit func(time.Time) bool) {
+	// Developers Note:
+	// RFC 5545 counts the sub-daily periods in elapsed time, so unlike the
+	// longer frequencies we step the instant and not the local time. This
+	// way the hour skipped by the "spring forward" transition never happens
+	// and the repeated "fall back" hour happens twice. The `BY*` rule parts
+	// are still checked against the local time of every period.
+	loc := dtstart.Location()
+	wallStart := wallClockOf(dtstart)
+	step := r.subDailyStep()
+	period := dtstart.Add(-wallStart.Sub(wallStart.Truncate(step)))
+	for ; period.Year() <= rruleMaxYear; period = r.nextSubDailyPeriod(period, interval, loc) {
+		wallPeriod := wallClockOf(period)
+		for _, wall := range r.periodCandidates(wallPeriod, wallStart) {
+			dt := period.Add(wall.Sub(wallPeriod))
+			if dt.Before(dtstart) {
+				continue
+			}
+			if !emit(dt) {
+				return
+			}
+		}
+	}
+}
+
+// untilIn returns the `UNTIL` of the rule as an instant, floating values are
+// converted into the location.
+func (r *RRule) untilIn(loc *time.Location) (time.Time, bool) {
+	if r.Until.IsZero() {
+		return time.Time{}, false
+	}
+	if !r.untilFloating {
+		return r.Until, true
+	}
+	u := r.Until
+	if r.untilDateOnly {
+		// A date includes every occurrence on that day.
+		return time.Date(u.Year(), u.Month(), u.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond), true
+	}
+	return time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, loc), true
+}
+
+// withDefaults returns a copy of the rule with the values RFC 5545 derives from
+// the `dtstart` when the rule does not specify which days to use. For example
+// `FREQ=MONTHLY` alone repeats on the day of the month of the `dtstart`.
+func (r *RRule) withDefaults(wallStart time.Time) *RRule {
+	x := *r
+	if len(x.ByWeekNo) == 0 && len(x.ByYearDay) == 0 && len(x.ByMonthDay) == 0 && len(x.ByDay) == 0 {
+		switch x.Freq {
+		case RRuleYearly:
+			if len(x.ByMonth) == 0 {
+				x.ByMonth = []int{int(wallStart.Month())}
+			}
+			x.ByMonthDay = []int{wallStart.Day()}
+		case RRuleMonthly:
+			x.ByMonthDay = []int{wallStart.Day()}
+		case RRuleWeekly:
+			x.ByDay = []RRuleWeekday{{Weekday: wallStart.Weekday()}}
+		}
+	}
+	return &x
+}
+
+// firstPeriod returns the start of the period which contains the `dtstart`
+// for the daily and longer frequencies.
+func (r *RRule) firstPeriod(wallStart time.Time) time.Time {
+	y, m, d := wallStart.Date()
+	switch r.Freq {
+	case RRuleYearly:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+	case RRuleMonthly:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	case RRuleWeekly:
+		offset := (int(wallStart.Weekday()) - int(r.WeekStart) + 7) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// nextPeriod returns the start of the period `interval` periods after for the
+// daily and longer frequencies.
+func (r *RRule) nextPeriod(period time.Time, interval int) time.Time {
+	switch r.Freq {
+	case RRuleYearly:
+		return period.AddDate(interval, 0, 0)
+	case RRuleMonthly:
+		return period.AddDate(0, interval, 0)
+	case RRuleWeekly:
+		return period.AddDate(0, 0, 7*interval)
+	}
+	return period.AddDate(0, 0, interval)
+}
+
+// nextSubDailyPeriod returns the instant `interval` periods after for the
+// sub-daily frequencies.
+func (r *RRule) nextSubDailyPeriod(period time.Time, interval int, loc *time.Location) time.Time {
+	// Developers Note:
+	// Sub-daily rules limited to certain days (ex: BYDAY=MO) would otherwise
+	// step through every hour, minute or second of the days which can never
+	// match, so we jump straight to the first period of the next matching day.
+	step := r.subDailyStep() * time.Duration(interval)
+	next := period.Add(step)
+	wall := wallClockOf(next)
+	if next.Day() != period.Day() || r.dayMatches(wall) {
+		return next
+	}
+	for day := time.Date(wall.Year(), wall.Month(), wall.Day()+1, 0, 0, 0, 0, time.UTC); day.Year() <= rruleMaxYear; day = day.AddDate(0, 0, 1) {
+		if r.dayMatches(day) {
+			skipped := (resolveScheduledWallClock(day, loc).Sub(next) + step - 1) / step
+			return next.Add(skipped * step)
+		}
+	}
+	return time.Date(rruleMaxYear+1, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// subDailyStep returns the length of one period for the sub-daily frequencies.
+func (r *RRule) subDailyStep() time.Duration {
+	switch r.Freq {
+	case RRuleHourly:
+		return time.Hour
+	case RRuleMinutely:
+		return time.Minute
+	}
+	return time.Second
+}
+
+// periodCandidates returns the sorted occurrences which fall in the period
+// after applying every `BY*` rule part (including `BYSETPOS`).
+func (r *RRule) periodCandidates(period time.Time, wallStart time.Time) []time.Time {
+	var days []time.Time
+	switch r.Freq {
+	case RRuleYearly:
+		for day := period; day.Year() == period.Year(); day = day.AddDate(0, 0, 1) {
+			days = append(days, day)
+		}
+	case RRuleMonthly:
+		for day := period; day.Month() == period.Month(); day = day.AddDate(0, 0, 1) {
+			days = append(days, day)
+		}
+	case RRuleWeekly:
+		for i := 0; i < 7; i++ {
+			days = append(days, period.AddDate(0, 0, i))
+		}
+	default:
+		days = []time.Time{time.Date(period.Year(), period.Month(), period.Day(), 0, 0, 0, 0, time.UTC)}
+	}
+
+	var results []time.Time
+	for _, day := range days {
+		if !r.dayMatches(day) {
+			continue
+		}
+		for _, tod := range r.timesOfDay(period, wallStart) {
+			results = append(results, day.Add(tod))
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Before(results[j])
+	})
+
+	if len(r.BySetPos) == 0 {
+		return results
+	}
+	var picked []time.Time
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(results) + pos
+		}
+		if i >= 0 && i < len(results) {
+			picked = append(picked, results[i])
+		}
+	}
+	sort.Slice(picked, func(i, j int) bool {
+		return picked[i].Before(picked[j])
+	})
+	return uniqueTimes(picked)
+}
+
+// timesOfDay returns the sorted times of day (as durations since midnight)
+// which occurrences of the period can happen on.
+func (r *RRule) timesOfDay(period time.Time, wallStart time.Time) []time.Duration {
+	// Developers Note:
+	// For daily and longer frequencies the `BYHOUR`, `BYMINUTE` and `BYSECOND`
+	// parts expand the occurrences and default to the time of the `dtstart`.
+	// For the shorter frequencies the period's own hour, minute or second is
+	// used and the parts only limit which periods are kept.
+	pick := func(values []int, periodValue int, startValue int, fromPeriod bool) []int {
+		if fromPeriod {
+			if len(values) == 0 || containsInt(values, periodValue) {
+				return []int{periodValue}
+			}
+			return nil
+		}
+		if len(values) == 0 {
+			return []int{startValue}
+		}
+		return values
+	}
+	hours := pick(r.ByHour, period.Hour(), wallStart.Hour(), r.Freq <= RRuleHourly)
+	minutes := pick(r.ByMinute, period.Minute(), wallStart.Minute(), r.Freq <= RRuleMinutely)
+	seconds := pick(r.BySecond, period.Second(), wallStart.Second(), r.Freq <= RRuleSecondly)
+
+	var results []time.Duration
+	for _, h := range hours {
+		for _, m := range minutes {
+			for _, s := range seconds {
+				results = append(results, time.Duration(h)*time.Hour+time.Duration(m)*time.Minute+time.Duration(s)*time.Second)
+			}
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i] < results[j]
+	})
+	return results
+}
+
+// dayMatches returns true if the day passes the `BYMONTH`, `BYWEEKNO`,
+// `BYYEARDAY`, `BYMONTHDAY` and `BYDAY` rule parts.
+func (r *RRule) dayMatches(day time.Time) bool {
+	if len(r.ByMonth) > 0 && !containsInt(r.ByMonth, int(day.Month())) {
+		return false
+	}
+	if len(r.ByWeekNo) > 0 {
+		wk, total := weekNumberWithWeekStart(day, r.WeekStart)
+		if !containsInt(r.ByWeekNo, wk) && !containsInt(r.ByWeekNo, wk-total-1) {
+			return false
+		}
+	}
+	if len(r.ByYearDay) > 0 {
+		daysInYear := time.Date(day.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		if !containsInt(r.ByYearDay, day.YearDay()) && !containsInt(r.ByYearDay, day.YearDay()-daysInYear-1) {
+			return false
+		}
+	}
+	if len(r.ByMonthDay) > 0 {
+		daysInMonth := daysIn(day.Year(), day.Month())
+		if !containsInt(r.ByMonthDay, day.Day()) && !containsInt(r.ByMonthDay, day.Day()-daysInMonth-1) {
+			return false
+		}
+	}
+	if len(r.ByDay) > 0 {
+		matched := false
+		for _, wd := range r.ByDay {
+			if wd.Weekday != day.Weekday() {
+				continue
+			}
+			if wd.N == 0 || r.ordinalMatches(day, wd.N) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// ordinalMatches returns true if the day is the n'th occurrence of its weekday
+// in the month (monthly rules or yearly rules with `BYMONTH`) or in the year.
+// Negative ordinals count backwards from the end.
+func (r *RRule) ordinalMatches(day time.Time, n int) bool {
+	switch {
+	case r.Freq == RRuleMonthly || (r.Freq == RRuleYearly && len(r.ByMonth) > 0):
+		first := (day.Day()-1)/7 + 1
+		last := -((daysIn(day.Year(), day.Month())-day.Day())/7 + 1)
+		return n == first || n == last
+	case r.Freq == RRuleYearly:
+		daysInYear := time.Date(day.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		first := (day.YearDay()-1)/7 + 1
+		last := -((daysInYear-day.YearDay())/7 + 1)
+		return n == first || n == last
+	}
+	// Ordinals are only meaningful for monthly and yearly rules.
+	return true
+}
+
+// parseUntil parses the `UNTIL` rule part which may be a date, a local date
+// and time or a UTC date and time.
+func (r *RRule) parseUntil(value string) error {
+	var err error
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		r.Until, err = time.Parse("20060102T150405Z", value)
+	case strings.Contains(value, "T"):
+		r.Until, err = time.Parse("20060102T150405", value)
+		r.untilFloating = true
+	default:
+		r.Until, err = time.Parse("20060102", value)
+		r.untilFloating = true
+		r.untilDateOnly = true
+	}
+	if err != nil {
+		return fmt.Errorf("%w: invalid UNTIL %q", ErrInvalidRRule, value)
+	}
+	return nil
+}
+
+// parseRRuleFrequency parses the `FREQ` rule part.
+func parseRRuleFrequency(value string) (RRuleFrequency, error) {
+	for f, name := range rruleFrequencyNames {
+		if strings.EqualFold(name, value) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid FREQ %q", ErrInvalidRRule, value)
+}
+
+// parseRRuleInt parses a single integer between the minimum and maximum.
+func parseRRuleInt(value string, min int, max int) (int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil || i < min || i > max {
+		return 0, fmt.Errorf("%w: invalid value %q", ErrInvalidRRule, value)
+	}
+	return i, nil
+}
+
+// parseRRuleInts parses a comma separated list of integers between the minimum
+// and maximum. If negatives are allowed then the values between `-max` and
+// `-min` are accepted as well.
+func parseRRuleInts(value string, min int, max int, allowNegative bool) ([]int, error) {
+	var results []int
+	for _, str := range strings.Split(value, ",") {
+		i, err := strconv.Atoi(strings.TrimPrefix(str, "+"))
+		if err == nil && allowNegative && i < 0 {
+			i = -i
+			if i >= min && i <= max {
+				results = append(results, -i)
+				continue
+			}
+		} else if err == nil && i >= min && i <= max {
+			results = append(results, i)
+			continue
+		}
+		return nil, fmt.Errorf("%w: invalid value %q", ErrInvalidRRule, str)
+	}
+	return results, nil
+}
+
+// parseRRuleWeekdays parses a comma separated list of `BYDAY` values.
+func parseRRuleWeekdays(value string) ([]RRuleWeekday, error) {
+	var results []RRuleWeekday
+	for _, str := range strings.Split(value, ",") {
+		wd, err := parseRRuleWeekday(str)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, wd)
+	}
+	return results, nil
+}
+
+// parseRRuleWeekday parses a single `BYDAY` value, for example "-1FR".
+func parseRRuleWeekday(str string) (RRuleWeekday, error) {
+	if len(str) < 2 {
+		return RRuleWeekday{}, fmt.Errorf("%w: invalid weekday %q", ErrInvalidRRule, str)
+	}
+	name := strings.ToUpper(str[len(str)-2:])
+	wd := RRuleWeekday{Weekday: -1}
+	for weekday, weekdayName := range rruleWeekdayNames {
+		if weekdayName == name {
+			wd.Weekday = weekday
+		}
+	}
+	if wd.Weekday < 0 {
+		return RRuleWeekday{}, fmt.Errorf("%w: invalid weekday %q", ErrInvalidRRule, str)
+	}
+	if ordinal := str[:len(str)-2]; ordinal != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(ordinal, "+"))
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return RRuleWeekday{}, fmt.Errorf("%w: invalid weekday %q", ErrInvalidRRule, str)
+		}
+		wd.N = n
+	}
+	return wd, nil
+}
+
+// weekNumberWithWeekStart returns the week number of the day and the total
+// number of weeks in that week numbering year. Like ISO 8601 the first week of
+// the year is the first week with at least four days in the year, but weeks
+// start on the inputted weekday instead of always on Monday.
+func weekNumberWithWeekStart(day time.Time, weekStart time.Weekday) (int, int) {
+	startOfWeek := func(dt time.Time) time.Time {
+		offset := (int(dt.Weekday()) - int(weekStart) + 7) % 7
+		return time.Date(dt.Year(), dt.Month(), dt.Day()-offset, 0, 0, 0, 0, time.UTC)
+	}
+	firstWeek := func(year int) time.Time {
+		return startOfWeek(time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC))
+	}
+
+	// The week belongs to the year which holds its fourth day.
+	ws := startOfWeek(day)
+	year := ws.AddDate(0, 0, 3).Year()
+	first := firstWeek(year)
+	wk := int(ws.Sub(first).Hours()/24)/7 + 1
+	total := int(firstWeek(year+1).Sub(first).Hours()/24) / 7
+	return wk, total
+}
+
+// daysIn returns the number of days in the month of the year.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// containsInt returns true if the value is found in the slice.
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueTimes returns the sorted date/times with the duplicates removed.
+func uniqueTimes(times []time.Time) []time.Time {
+	results := make([]time.Time, 0, len(times))
+	for i, dt := range times {
+		if i == 0 || !dt.Equal(times[i-1]) {
+			results = append(results, dt)
+		}
+	}
+	return results
+}
@@ -0,0 +1,233 @@
+package timekit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	r, err := ParseRRule("RRULE:FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=1SU,-1SU;BYSETPOS=1,-1;WKST=SU")
+	if err != nil {
+		t.Fatalf("Failed parsing rule: %v", err)
+	}
+	if r.Freq != RRuleMonthly || r.Interval != 2 || r.Count != 10 || r.WeekStart != time.Sunday {
+		t.Errorf("Incorrect rule, got %+v", r)
+	}
+	if len(r.ByDay) != 2 || r.ByDay[0] != (RRuleWeekday{time.Sunday, 1}) || r.ByDay[1] != (RRuleWeekday{time.Sunday, -1}) {
+		t.Errorf("Incorrect weekdays, got %v", r.ByDay)
+	}
+
+	invalid := []string{
+		"",
+		"INTERVAL=2",                         // Missing FREQ.
+		"FREQ=FORTNIGHTLY",                   // Unknown frequency.
+		"FREQ=DAILY;INTERVAL=0",              // Interval must be positive.
+		"FREQ=DAILY;COUNT=2;UNTIL=20240101",  // Cannot have both.
+		"FREQ=MONTHLY;BYMONTHDAY=0",          // Zero is not a day.
+		"FREQ=MONTHLY;BYDAY=0MO",             // Zero is not an ordinal.
+		"FREQ=MONTHLY;BYDAY=XX",              // Unknown weekday.
+		"FREQ=YEARLY;BYMONTH=13",             // Out of range.
+		"FREQ=DAILY;FOO=BAR",                 // Unknown part.
+		"FREQ=DAILY;UNTIL=2024-01-01T000000", // Malformed date.
+	}
+	for _, s := range invalid {
+		if _, err := ParseRRule(s); !errors.Is(err, ErrInvalidRRule) {
+			t.Errorf("Incorrect result for %q, got %v but was expecting %v", s, err, ErrInvalidRRule)
+		}
+	}
+}
+
+func TestRRuleString(t *testing.T) {
+	rules := []string{
+		"FREQ=DAILY;COUNT=10",
+		"FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;BYDAY=TU,TH;WKST=SU",
+		"FREQ=MONTHLY;UNTIL=19971224T090000;BYDAY=-2MO",
+		"FREQ=YEARLY;UNTIL=20000131;BYDAY=SU,MO,TU,WE,TH,FR,SA;BYMONTH=1",
+		"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		"FREQ=YEARLY;BYHOUR=9,10;BYYEARDAY=1,100,-1;BYWEEKNO=20",
+	}
+	for _, s := range rules {
+		r, err := ParseRRule(s)
+		if err != nil {
+			t.Fatalf("Failed parsing rule %q: %v", s, err)
+		}
+		if actual := r.String(); actual != s {
+			t.Errorf("Incorrect rule, got %q but was expecting %q", actual, s)
+		}
+	}
+}
+
+func TestRRuleAll(t *testing.T) {
+	// Developers Note:
+	// The following cases come from the examples found in section 3.8.5.3 of
+	// RFC 5545 via https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.3
+	loc, _ := time.LoadLocation("America/New_York")
+	d := func(year int, month time.Month, day int, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, loc)
+	}
+	dtstart := d(1997, 9, 2, 9) // Tuesday Sep 2nd 1997 - 9 AM
+
+	tests := []struct {
+		rule     string
+		dtstart  time.Time
+		limit    int
+		expected []time.Time
+	}{
+		{"FREQ=DAILY;COUNT=3", dtstart, 0, []time.Time{d(1997, 9, 2, 9), d(1997, 9, 3, 9), d(1997, 9, 4, 9)}},
+		{"FREQ=WEEKLY;INTERVAL=2;WKST=SU;BYDAY=TU,TH;COUNT=8", dtstart, 0, []time.Time{
+			d(1997, 9, 2, 9), d(1997, 9, 4, 9), d(1997, 9, 16, 9), d(1997, 9, 18, 9),
+			d(1997, 9, 30, 9), d(1997, 10, 2, 9), d(1997, 10, 14, 9), d(1997, 10, 16, 9),
+		}},
+		{"FREQ=MONTHLY;COUNT=6;BYDAY=-2MO", dtstart, 0, []time.Time{
+			d(1997, 9, 22, 9), d(1997, 10, 20, 9), d(1997, 11, 17, 9), d(1997, 12, 22, 9), d(1998, 1, 19, 9), d(1998, 2, 16, 9),
+		}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-3", dtstart, 6, []time.Time{
+			d(1997, 9, 28, 9), d(1997, 10, 29, 9), d(1997, 11, 28, 9), d(1997, 12, 29, 9), d(1998, 1, 29, 9), d(1998, 2, 26, 9),
+		}},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", dtstart, 7, []time.Time{
+			d(1997, 9, 30, 9), d(1997, 10, 31, 9), d(1997, 11, 28, 9), d(1997, 12, 31, 9), d(1998, 1, 30, 9), d(1998, 2, 27, 9), d(1998, 3, 31, 9),
+		}},
+		{"FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", dtstart, 5, []time.Time{
+			d(1998, 2, 13, 9), d(1998, 3, 13, 9), d(1998, 11, 13, 9), d(1999, 8, 13, 9), d(2000, 10, 13, 9),
+		}},
+		{"FREQ=YEARLY;BYDAY=20MO", d(1997, 5, 19, 9), 3, []time.Time{d(1997, 5, 19, 9), d(1998, 5, 18, 9), d(1999, 5, 17, 9)}},
+		{"FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO", d(1997, 5, 12, 9), 3, []time.Time{d(1997, 5, 12, 9), d(1998, 5, 11, 9), d(1999, 5, 17, 9)}},
+		{"FREQ=YEARLY;BYMONTH=3;BYDAY=TH", d(1997, 3, 13, 9), 6, []time.Time{
+			d(1997, 3, 13, 9), d(1997, 3, 20, 9), d(1997, 3, 27, 9), d(1998, 3, 5, 9), d(1998, 3, 12, 9), d(1998, 3, 19, 9),
+		}},
+		{"FREQ=YEARLY;INTERVAL=3;COUNT=4;BYYEARDAY=1,100,200", d(1997, 1, 1, 9), 0, []time.Time{
+			d(1997, 1, 1, 9), d(1997, 4, 10, 9), d(1997, 7, 19, 9), d(2000, 1, 1, 9),
+		}},
+		{"FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T210000Z", dtstart, 0, []time.Time{d(1997, 9, 2, 9), d(1997, 9, 2, 12), d(1997, 9, 2, 15)}},
+		{"FREQ=DAILY;BYHOUR=9,10,11;BYMINUTE=0,20,40", dtstart, 4, []time.Time{
+			d(1997, 9, 2, 9), d(1997, 9, 2, 9).Add(20 * time.Minute), d(1997, 9, 2, 9).Add(40 * time.Minute), d(1997, 9, 2, 10),
+		}},
+		{"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", dtstart, 0, nil}, // February 30th never happens.
+	}
+	for _, tc := range tests {
+		r, err := ParseRRule(tc.rule)
+		if err != nil {
+			t.Fatalf("Failed parsing rule %q: %v", tc.rule, err)
+		}
+		var actual []time.Time
+		for dt := range r.All(tc.dtstart) {
+			actual = append(actual, dt)
+			if len(actual) == tc.limit {
+				break
+			}
+		}
+		if !timeEqual(tc.expected, actual) {
+			t.Errorf("Incorrect dates for %q, got %s but was expecting %s", tc.rule, actual, tc.expected)
+		}
+	}
+}
+
+func TestRRuleUntilAcrossDaylightSaving(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	dtstart := time.Date(1997, 9, 2, 9, 0, 0, 0, loc) // Tuesday Sep 2nd 1997 - 9 AM EDT
+
+	r, _ := ParseRRule("FREQ=DAILY;UNTIL=19971224T000000Z")
+	actual := collectTimes(r.All(dtstart))
+	if len(actual) != 113 {
+		t.Errorf("Incorrect number of dates, got %d but was expecting %d", len(actual), 113)
+	}
+	for _, dt := range actual {
+		if dt.Hour() != 9 {
+			t.Fatalf("Incorrect time of day, got %s but was expecting 9 AM", dt)
+		}
+	}
+}
+
+func TestRRuleSubDailyAcrossDaylightSaving(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	utc := func(month time.Month, day int, hour int, min int) time.Time {
+		return time.Date(2023, month, day, hour, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		rule     string
+		dtstart  time.Time
+		expected []time.Time
+	}{
+		////
+		//// Case 1: Spring forward skips 2 AM and does not repeat 3 AM.
+		////
+		{"hourly spring forward", "FREQ=HOURLY;COUNT=4", time.Date(2023, 3, 12, 0, 0, 0, 0, loc), []time.Time{
+			utc(3, 12, 5, 0), // 12:00 AM EST
+			utc(3, 12, 6, 0), // 1:00 AM EST
+			utc(3, 12, 7, 0), // 3:00 AM EDT
+			utc(3, 12, 8, 0), // 4:00 AM EDT
+		}},
+		////
+		//// Case 2: Fall back repeats 1 AM once per offset.
+		////
+		{"hourly fall back", "FREQ=HOURLY;COUNT=4", time.Date(2023, 11, 5, 0, 0, 0, 0, loc), []time.Time{
+			utc(11, 5, 4, 0), // 12:00 AM EDT
+			utc(11, 5, 5, 0), // 1:00 AM EDT
+			utc(11, 5, 6, 0), // 1:00 AM EST
+			utc(11, 5, 7, 0), // 2:00 AM EST
+		}},
+		////
+		//// Case 3: Minutely rules step in elapsed time across the gap.
+		////
+		{"minutely spring forward", "FREQ=MINUTELY;INTERVAL=20;COUNT=4", time.Date(2023, 3, 12, 1, 20, 0, 0, loc), []time.Time{
+			utc(3, 12, 6, 20), // 1:20 AM EST
+			utc(3, 12, 6, 40), // 1:40 AM EST
+			utc(3, 12, 7, 0),  // 3:00 AM EDT
+			utc(3, 12, 7, 20), // 3:20 AM EDT
+		}},
+		////
+		//// Case 4: Minutely rules walk through the repeated hour twice.
+		////
+		{"minutely fall back", "FREQ=MINUTELY;INTERVAL=30;COUNT=5", time.Date(2023, 11, 5, 0, 30, 0, 0, loc), []time.Time{
+			utc(11, 5, 4, 30), // 12:30 AM EDT
+			utc(11, 5, 5, 0),  // 1:00 AM EDT
+			utc(11, 5, 5, 30), // 1:30 AM EDT
+			utc(11, 5, 6, 0),  // 1:00 AM EST
+			utc(11, 5, 6, 30), // 1:30 AM EST
+		}},
+		////
+		//// Case 5: BYHOUR matches both copies of the repeated hour.
+		////
+		{"hourly byhour fall back", "FREQ=HOURLY;BYHOUR=1;COUNT=3", time.Date(2023, 11, 4, 12, 0, 0, 0, loc), []time.Time{
+			utc(11, 5, 5, 0), // 1:00 AM EDT
+			utc(11, 5, 6, 0), // 1:00 AM EST
+			utc(11, 6, 6, 0), // 1:00 AM EST
+		}},
+	}
+	for _, tc := range tests {
+		r, err := ParseRRule(tc.rule)
+		if err != nil {
+			t.Fatalf("Failed parsing rule %q: %v", tc.rule, err)
+		}
+		actual := collectTimes(r.All(tc.dtstart))
+		if len(actual) != len(tc.expected) {
+			t.Errorf("Incorrect dates for %s, got %s but was expecting %s", tc.name, actual, tc.expected)
+			continue
+		}
+		for i := range actual {
+			if !actual[i].Equal(tc.expected[i]) || actual[i].Location() != loc {
+				t.Errorf("Incorrect dates for %s, got %s but was expecting %s", tc.name, actual, tc.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestRRuleBetween(t *testing.T) {
+	loc := time.UTC                                    // closure can be used if necessary
+	dtstart := time.Date(2024, 1, 9, 10, 0, 0, 0, loc) // Tuesday Jan 9th 2024 - 10 AM
+	r, _ := ParseRRule("FREQ=MONTHLY;BYDAY=2TU")       // Second Tuesday of the month, forever.
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, loc)    // May 1st 2024
+	end := time.Date(2024, 8, 13, 10, 0, 0, 0, loc)    // Aug 13th 2024 - 10 AM (excluded)
+	actual := r.Between(dtstart, start, end)
+	expected := []time.Time{
+		time.Date(2024, 5, 14, 10, 0, 0, 0, loc),
+		time.Date(2024, 6, 11, 10, 0, 0, 0, loc),
+		time.Date(2024, 7, 9, 10, 0, 0, 0, loc),
+	}
+	if !timeEqual(expected, actual) {
+		t.Errorf("Incorrect dates, got %s but was expecting %s", actual, expected)
+	}
+}
@@ -0,0 +1,315 @@
+package timekit
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+// The errors returned (wrapped with more details) when a `ScheduleSpec` is
+// not valid. Use `errors.Is` to check which kind of problem occurred.
+var (
+	ErrInvalidFrequency    = errors.New("timekit: invalid schedule frequency")
+	ErrInvalidWeekday      = errors.New("timekit: invalid schedule weekday")
+	ErrInvalidMonth        = errors.New("timekit: invalid schedule month")
+	ErrInvalidMonthDay     = errors.New("timekit: invalid schedule day of month")
+	ErrInvalidNth          = errors.New("timekit: invalid schedule weekday ordinal")
+	ErrInvalidEndCondition = errors.New("timekit: invalid schedule end condition")
+)
+
+// ScheduleFrequency represents the type of period a schedule repeats on.
+type ScheduleFrequency int
+
+// The frequencies supported by the `ScheduleSpec`. The zero value is not a
+// valid frequency so forgetting to set it is reported as an error.
+const (
+	DailySchedule ScheduleFrequency = iota + 1
+	WeeklySchedule
+	MonthlySchedule
+	YearlySchedule
+)
+
+// ScheduleSpec describes a recurring schedule which is turned into dates by
+// the `Generate` function. Every date keeps the time of day and location of
+// the `Start` date and dates before `Start` are never included.
+//
+// The fields which pick the dates inside of a period depend on the frequency:
+//
+//   - DailySchedule uses every day, or only the `Weekdays` when set.
+//   - WeeklySchedule uses the `Weekdays` (or the weekday of `Start` when not
+//     set) of every seven days counted from `Start`, like the
+//     `GetDatesByWeeklyBasedRecurringSchedule` function.
+//   - MonthlySchedule uses the `MonthDay` (or the day of `Start` when not set)
+//     or, when `Nth` is set, the nth occurrence of each of the `Weekdays`.
+//   - YearlySchedule does the same as the monthly schedule but only in the
+//     `Month` (or the month of `Start` when not set).
+//
+// Exactly one of the end conditions `Count`, `Until` or `Periods` must be set.
+type ScheduleSpec struct {
+	// Start is the date/time the schedule begins at.
+	Start time.Time
+
+	// Frequency is the type of period the schedule repeats on.
+	Frequency ScheduleFrequency
+
+	// Interval is the number of periods between repeats, for example an
+	// interval of 2 with a weekly schedule is every other week. Zero is
+	// treated as 1.
+	Interval int
+
+	// Weekdays are the days of the week from 0 (Sunday) to 6 (Saturday).
+	Weekdays []int8
+
+	// Month is the month from 1 to 12 used by the yearly schedule.
+	Month int
+
+	// MonthDay is the day of the month from 1 to 31 used by the monthly and
+	// yearly schedules. Months without the day follow the `MissingDayPolicy`,
+	// except for Feb 29th in yearly schedules which follows the
+	// `LeapDayPolicy`.
+	MonthDay int
+
+	// Nth is the occurrence of the `Weekdays` in the month used by the
+	// monthly and yearly schedules, from 1 to 5 or -1 to -5 to count from
+	// the end of the month. Months without the occurrence follow the
+	// `MissingWeekdayPolicy`.
+	Nth int
+
+	// Count is the total number of dates to generate.
+	Count int
+
+	// Until is the last date/time (inclusive) that can be generated.
+	Until time.Time
+
+	// Periods is the number of periods (including the ones skipped by the
+	// `Interval`) the schedule runs for.
+	Periods int
+
+	// MissingDayPolicy controls the months without the `MonthDay`.
+	MissingDayPolicy MissingDayPolicy
+
+	// MissingWeekdayPolicy controls the months without the `Nth` weekday.
+	MissingWeekdayPolicy MissingWeekdayPolicy
+
+	// LeapDayPolicy controls the years without Feb 29th.
+	LeapDayPolicy LeapDayPolicy
+}
+
+// Generate returns the dates of the schedule or an error if the schedule is
+// not valid.
+func Generate(spec ScheduleSpec) ([]time.Time, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return collectTimes(spec.All()), nil
+}
+
+// Validate returns an error wrapping one of the `ErrInvalid...` errors if the
+// schedule is not valid.
+func (spec ScheduleSpec) Validate() error {
+	if spec.Frequency < DailySchedule || spec.Frequency > YearlySchedule {
+		return fmt.Errorf("%w: unknown frequency %d", ErrInvalidFrequency, spec.Frequency)
+	}
+	if spec.Interval < 0 {
+		return fmt.Errorf("%w: interval %d is negative", ErrInvalidFrequency, spec.Interval)
+	}
+	for _, weekday := range spec.Weekdays {
+		if weekday < 0 || weekday > 6 {
+			return fmt.Errorf("%w: %d is not between 0 and 6", ErrInvalidWeekday, weekday)
+		}
+	}
+	if spec.Month < 0 || spec.Month > 12 {
+		return fmt.Errorf("%w: %d is not between 1 and 12", ErrInvalidMonth, spec.Month)
+	}
+	if spec.MonthDay < 0 || spec.MonthDay > 31 {
+		return fmt.Errorf("%w: %d is not between 1 and 31", ErrInvalidMonthDay, spec.MonthDay)
+	}
+	if spec.Frequency == YearlySchedule && spec.MonthDay > daysIn(2000, spec.yearlyMonth()) {
+		// Developers Note:
+		// We check against a leap year so Feb 29th is allowed.
+		return fmt.Errorf("%w: %s does not have %d days", ErrInvalidMonthDay, spec.yearlyMonth(), spec.MonthDay)
+	}
+	if spec.Nth < -5 || spec.Nth > 5 {
+		return fmt.Errorf("%w: %d is not between -5 and 5", ErrInvalidNth, spec.Nth)
+	}
+	if spec.Nth != 0 {
+		if spec.Frequency != MonthlySchedule && spec.Frequency != YearlySchedule {
+			return fmt.Errorf("%w: only monthly and yearly schedules support an ordinal", ErrInvalidNth)
+		}
+		if spec.MonthDay != 0 {
+			return fmt.Errorf("%w: cannot be combined with a day of month", ErrInvalidNth)
+		}
+		if len(spec.Weekdays) == 0 {
+			return fmt.Errorf("%w: an ordinal requires at least one weekday", ErrInvalidWeekday)
+		}
+	}
+
+	if spec.Count < 0 || spec.Periods < 0 {
+		return fmt.Errorf("%w: count and periods cannot be negative", ErrInvalidEndCondition)
+	}
+	conditions := 0
+	if spec.Count > 0 {
+		conditions++
+	}
+	if !spec.Until.IsZero() {
+		conditions++
+	}
+	if spec.Periods > 0 {
+		conditions++
+	}
+	if conditions != 1 {
+		return fmt.Errorf("%w: exactly one of count, until or periods must be set", ErrInvalidEndCondition)
+	}
+	return nil
+}
+
+// All returns an iterator which lazily yields the dates of the schedule. An
+// invalid schedule yields nothing, use the `Validate` function to find out why.
+func (spec ScheduleSpec) All() iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if spec.Validate() != nil {
+			return
+		}
+
+		interval := spec.Interval
+		if interval < 1 {
+			interval = 1
+		}
+
+		count := 0
+		for period := 0; spec.Periods == 0 || period < spec.Periods; period += interval {
+			dates, ok := spec.datesInPeriod(period)
+			if !ok {
+				return
+			}
+			for _, dt := range dates {
+				if dt.Before(spec.Start) {
+					continue
+				}
+				if !spec.Until.IsZero() && dt.After(spec.Until) {
+					return
+				}
+				if !yield(dt) {
+					return
+				}
+				count++
+				if spec.Count > 0 && count >= spec.Count {
+					return
+				}
+			}
+		}
+	}
+}
+
+// datesInPeriod returns the sorted dates of the schedule which fall inside
+// the period, counted from zero at the start of the schedule. The boolean is
+// false once the period goes past the year 9999.
+func (spec ScheduleSpec) datesInPeriod(period int) ([]time.Time, bool) {
+	start := spec.Start
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	}
+
+	var dates []time.Time
+	var year int
+	switch spec.Frequency {
+	case DailySchedule, WeeklySchedule:
+		days := 1
+		if spec.Frequency == WeeklySchedule {
+			days = 7
+		}
+		weekdays := spec.Weekdays
+		if spec.Frequency == WeeklySchedule && len(weekdays) == 0 {
+			weekdays = []int8{int8(start.Weekday())}
+		}
+		year = at(start.Year(), start.Month(), start.Day()+period*days).Year()
+		for i := 0; i < days; i++ {
+			// Developers Note:
+			// We use `time.Date` to step through the days so every date keeps
+			// the same time of day across daylight saving transitions.
+			dt := at(start.Year(), start.Month(), start.Day()+period*days+i)
+			if len(weekdays) == 0 || slices.Contains(weekdays, int8(dt.Weekday())) {
+				dates = append(dates, dt)
+			}
+		}
+
+	case MonthlySchedule:
+		monthDT := time.Date(start.Year(), start.Month()+time.Month(period), 1, 0, 0, 0, 0, time.UTC)
+		year = monthDT.Year()
+		for _, day := range spec.daysInMonth(monthDT.Year(), monthDT.Month()) {
+			dates = append(dates, at(monthDT.Year(), monthDT.Month(), day))
+		}
+
+	case YearlySchedule:
+		year = start.Year() + period
+		month := spec.yearlyMonth()
+		if spec.Nth == 0 && month == time.February && spec.monthDay() == 29 && daysIn(year, month) < 29 {
+			switch spec.LeapDayPolicy {
+			case UseFeb28ForLeapDay:
+				dates = append(dates, at(year, time.February, 28))
+			case UseMar1ForLeapDay:
+				dates = append(dates, at(year, time.March, 1))
+			}
+			break
+		}
+		for _, day := range spec.daysInMonth(year, month) {
+			dates = append(dates, at(year, month, day))
+		}
+	}
+
+	if year > rruleMaxYear {
+		return nil, false
+	}
+	return dates, true
+}
+
+// daysInMonth returns the sorted days of the month picked by the `MonthDay`
+// or the `Nth` and `Weekdays` fields of the monthly and yearly schedules.
+func (spec ScheduleSpec) daysInMonth(year int, month time.Month) []int {
+	if spec.Nth == 0 {
+		day := spec.monthDay()
+		if day > daysIn(year, month) {
+			if spec.MissingDayPolicy != UseLastDayForMissing {
+				return nil
+			}
+			day = daysIn(year, month)
+		}
+		return []int{day}
+	}
+
+	days := make([]int, 0, len(spec.Weekdays))
+	for _, weekday := range spec.Weekdays {
+		day, ok := nthWeekdayOfMonth(year, month, spec.Nth, time.Weekday(weekday))
+		if !ok {
+			if spec.MissingWeekdayPolicy != UseLastWeekdayForMissing {
+				continue
+			}
+			day, _ = nthWeekdayOfMonth(year, month, -1, time.Weekday(weekday))
+			if spec.Nth < 0 {
+				day, _ = nthWeekdayOfMonth(year, month, 1, time.Weekday(weekday))
+			}
+		}
+		days = append(days, day)
+	}
+	slices.Sort(days)
+	return slices.Compact(days)
+}
+
+// monthDay returns the day of the month used by the monthly and yearly
+// schedules when they are not using the `Nth` weekday.
+func (spec ScheduleSpec) monthDay() int {
+	if spec.MonthDay == 0 {
+		return spec.Start.Day()
+	}
+	return spec.MonthDay
+}
+
+// yearlyMonth returns the month used by the yearly schedule.
+func (spec ScheduleSpec) yearlyMonth() time.Month {
+	if spec.Month == 0 {
+		return spec.Start.Month()
+	}
+	return time.Month(spec.Month)
+}
@@ -0,0 +1,193 @@
+package timekit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		spec     ScheduleSpec
+		expected []time.Time
+	}{
+		{
+			name: "daily weekdays with count",
+			spec: ScheduleSpec{
+				Start:     time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC), // Friday
+				Frequency: DailySchedule,
+				Weekdays:  []int8{1, 2, 3, 4, 5},
+				Count:     3,
+			},
+			expected: []time.Time{
+				time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "daily across daylight saving keeps the time of day",
+			spec: ScheduleSpec{
+				Start:     time.Date(2024, 3, 9, 9, 0, 0, 0, loc),
+				Frequency: DailySchedule,
+				Periods:   3,
+			},
+			expected: []time.Time{
+				time.Date(2024, 3, 9, 9, 0, 0, 0, loc),
+				time.Date(2024, 3, 10, 9, 0, 0, 0, loc),
+				time.Date(2024, 3, 11, 9, 0, 0, 0, loc),
+			},
+		},
+		{
+			name: "every other week with periods",
+			spec: ScheduleSpec{
+				Start:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), // Monday
+				Frequency: WeeklySchedule,
+				Interval:  2,
+				Weekdays:  []int8{1, 3},
+				Periods:   4,
+			},
+			expected: []time.Time{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "monthly day skips short months",
+			spec: ScheduleSpec{
+				Start:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Frequency: MonthlySchedule,
+				MonthDay:  31,
+				Until:     time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+			},
+			expected: []time.Time{
+				time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "monthly day uses the last day of short months",
+			spec: ScheduleSpec{
+				Start:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Frequency:        MonthlySchedule,
+				MonthDay:         31,
+				MissingDayPolicy: UseLastDayForMissing,
+				Count:            3,
+			},
+			expected: []time.Time{
+				time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "monthly second tuesday and thursday",
+			spec: ScheduleSpec{
+				Start:     time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+				Frequency: MonthlySchedule,
+				Nth:       2,
+				Weekdays:  []int8{4, 2},
+				Count:     3,
+			},
+			expected: []time.Time{
+				time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 8, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "yearly leap day on feb 28th",
+			spec: ScheduleSpec{
+				Start:         time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				Frequency:     YearlySchedule,
+				LeapDayPolicy: UseFeb28ForLeapDay,
+				Count:         2,
+			},
+			expected: []time.Time{
+				time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "yearly last friday of november",
+			spec: ScheduleSpec{
+				Start:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Frequency: YearlySchedule,
+				Month:     11,
+				Nth:       -1,
+				Weekdays:  []int8{5},
+				Periods:   2,
+			},
+			expected: []time.Time{
+				time.Date(2024, 11, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "yearly leap day that never occurs ends",
+			spec: ScheduleSpec{
+				Start:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Frequency: YearlySchedule,
+				Interval:  4,
+				Month:     2,
+				MonthDay:  29,
+				Count:     1,
+			},
+			expected: []time.Time{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Generate(tc.spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if timeEqual(tc.expected, actual) == false {
+				t.Errorf("Incorrect date, got %s but was expecting %s", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		spec     ScheduleSpec
+		expected error
+	}{
+		{"missing frequency", ScheduleSpec{Start: start, Count: 1}, ErrInvalidFrequency},
+		{"negative interval", ScheduleSpec{Start: start, Frequency: WeeklySchedule, Interval: -1, Count: 1}, ErrInvalidFrequency},
+		{"weekday out of range", ScheduleSpec{Start: start, Frequency: WeeklySchedule, Weekdays: []int8{7}, Count: 1}, ErrInvalidWeekday},
+		{"month out of range", ScheduleSpec{Start: start, Frequency: YearlySchedule, Month: 13, Count: 1}, ErrInvalidMonth},
+		{"day not in month", ScheduleSpec{Start: start, Frequency: YearlySchedule, Month: 4, MonthDay: 31, Count: 1}, ErrInvalidMonthDay},
+		{"ordinal out of range", ScheduleSpec{Start: start, Frequency: MonthlySchedule, Nth: 6, Weekdays: []int8{1}, Count: 1}, ErrInvalidNth},
+		{"ordinal without weekday", ScheduleSpec{Start: start, Frequency: MonthlySchedule, Nth: 1, Count: 1}, ErrInvalidWeekday},
+		{"no end condition", ScheduleSpec{Start: start, Frequency: DailySchedule}, ErrInvalidEndCondition},
+		{"two end conditions", ScheduleSpec{Start: start, Frequency: DailySchedule, Count: 1, Periods: 1}, ErrInvalidEndCondition},
+		{"negative count", ScheduleSpec{Start: start, Frequency: DailySchedule, Count: -1}, ErrInvalidEndCondition},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Generate(tc.spec)
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("Incorrect error, got %v but was expecting %v", err, tc.expected)
+			}
+			if actual != nil {
+				t.Errorf("Incorrect dates, got %s but was expecting nil", actual)
+			}
+		})
+	}
+}
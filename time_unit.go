@@ -0,0 +1,190 @@
+package timekit
+
+import "time"
+
+// TimeUnit represents a calendar unit which the `StartOf`, `EndOf`, `Next`
+// and `Previous` functions work with.
+type TimeUnit int
+
+const (
+	// UnitSecond is a second.
+	UnitSecond TimeUnit = iota + 1
+
+	// UnitMinute is a minute.
+	UnitMinute
+
+	// UnitHour is an hour.
+	UnitHour
+
+	// UnitDay is a day which starts at midnight.
+	UnitDay
+
+	// UnitWeek is a week which starts on Monday by default, see the
+	// `WithWeekStart` option.
+	UnitWeek
+
+	// UnitMonth is a month.
+	UnitMonth
+
+	// UnitQuarter is a quarter of the year starting in January, April, July
+	// or October.
+	UnitQuarter
+
+	// UnitHalfYear is half of a year starting in January or July.
+	UnitHalfYear
+
+	// UnitYear is a year.
+	UnitYear
+
+	// UnitDecade is a decade starting on a year which ends in zero, for
+	// example 2020 to 2029.
+	UnitDecade
+)
+
+// timeUnitNames is a mapping of the time units to their names.
+var timeUnitNames = map[TimeUnit]string{
+	UnitSecond:   "second",
+	UnitMinute:   "minute",
+	UnitHour:     "hour",
+	UnitDay:      "day",
+	UnitWeek:     "week",
+	UnitMonth:    "month",
+	UnitQuarter:  "quarter",
+	UnitHalfYear: "half-year",
+	UnitYear:     "year",
+	UnitDecade:   "decade",
+}
+
+// String returns the name of the time unit, for example "quarter".
+func (u TimeUnit) String() string {
+	return timeUnitNames[u]
+}
+
+// timeUnitConfig holds the settings of the time unit functions.
+type timeUnitConfig struct {
+	weekStart time.Weekday
+}
+
+// TimeUnitOption is a function which changes the settings of the `StartOf`,
+// `EndOf`, `Next` and `Previous` functions.
+type TimeUnitOption func(*timeUnitConfig)
+
+// WithWeekStart returns an option which sets the first day of the week, for
+// example `time.Sunday` which is used in Canada and the USA. By default weeks
+// start on Monday according to ISO 8601.
+func WithWeekStart(weekday time.Weekday) TimeUnitOption {
+	return func(cfg *timeUnitConfig) {
+		cfg.weekStart = weekday
+	}
+}
+
+// StartOf returns the first instant of the unit that the date/time falls in,
+// for example the start of the quarter of May 15th 2024 at 3:30 PM is April
+// 1st 2024 at midnight. Days (and every larger unit) start at midnight in the
+// location of the date/time, or when midnight is skipped by a daylight saving
+// transition at the end of the gap. Seconds, minutes and hours are truncated
+// on the instant so the repeated hour of the "fall back" transition starts
+// twice. Unknown units return the date/time unchanged.
+func StartOf(t time.Time, unit TimeUnit, opts ...TimeUnitOption) time.Time {
+	cfg := timeUnitConfig{weekStart: time.Monday}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// Developers Note:
+	// Like the `HourlyRangeForTime` function we subtract the smaller fields
+	// from the instant instead of rebuilding it with `time.Date`, which would
+	// always pick the first of the repeated hours.
+	nanos := time.Duration(t.Nanosecond())
+	seconds := time.Duration(t.Second()) * time.Second
+	minutes := time.Duration(t.Minute()) * time.Minute
+	switch unit {
+	case UnitSecond:
+		return t.Add(-nanos)
+	case UnitMinute:
+		return t.Add(-(seconds + nanos))
+	case UnitHour:
+		return t.Add(-(minutes + seconds + nanos))
+	}
+
+	year, month, day := t.Date()
+	switch unit {
+	case UnitDay:
+	case UnitWeek:
+		day -= (int(t.Weekday()) - int(cfg.weekStart) + 7) % 7
+	case UnitMonth:
+		day = 1
+	case UnitQuarter:
+		month, day = month-(month-1)%3, 1
+	case UnitHalfYear:
+		month, day = month-(month-1)%6, 1
+	case UnitYear:
+		month, day = time.January, 1
+	case UnitDecade:
+		year, month, day = year-((year%10)+10)%10, time.January, 1
+	default:
+		return t
+	}
+	return midnightOf(year, month, day, t.Location())
+}
+
+// EndOf returns the last instant (one nanosecond before the next unit starts)
+// of the unit that the date/time falls in, for example the end of the day is
+// 23:59:59.999999999. This is a closed bound like the `Inclusive` function of
+// the `TimeRange`, use `Next(t, unit, 1)` for the half-open end instead.
+func EndOf(t time.Time, unit TimeUnit, opts ...TimeUnitOption) time.Time {
+	if _, ok := timeUnitNames[unit]; !ok {
+		return t
+	}
+	return Next(t, unit, 1, opts...).Add(-time.Nanosecond)
+}
+
+// Next returns the start of the nth unit after the one that the date/time
+// falls in, for example the next month of Jan 31st is Feb 1st at midnight.
+// A `n` of zero returns the same as the `StartOf` function and a negative `n`
+// goes backwards.
+func Next(t time.Time, unit TimeUnit, n int, opts ...TimeUnitOption) time.Time {
+	start := StartOf(t, unit, opts...)
+	switch unit {
+	case UnitSecond:
+		return start.Add(time.Duration(n) * time.Second)
+	case UnitMinute:
+		return start.Add(time.Duration(n) * time.Minute)
+	case UnitHour:
+		return start.Add(time.Duration(n) * time.Hour)
+	}
+
+	year, month, day := start.Date()
+	switch unit {
+	case UnitDay:
+		day += n
+	case UnitWeek:
+		day += 7 * n
+	case UnitMonth:
+		month += time.Month(n)
+	case UnitQuarter:
+		month += time.Month(3 * n)
+	case UnitHalfYear:
+		month += time.Month(6 * n)
+	case UnitYear:
+		year += n
+	case UnitDecade:
+		year += 10 * n
+	default:
+		return t
+	}
+	return midnightOf(year, month, day, start.Location())
+}
+
+// Previous returns the start of the nth unit before the one that the
+// date/time falls in, for example the previous quarter of May 15th is January
+// 1st at midnight.
+func Previous(t time.Time, unit TimeUnit, n int, opts ...TimeUnitOption) time.Time {
+	return Next(t, unit, -n, opts...)
+}
+
+// midnightOf returns midnight of the date in the location, or the end of the
+// gap if midnight is skipped by a daylight saving transition.
+func midnightOf(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return resolveScheduledWallClock(time.Date(year, month, day, 0, 0, 0, 0, time.UTC), loc)
+}
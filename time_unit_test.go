@@ -0,0 +1,135 @@
+package timekit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartOfAndEndOf(t *testing.T) {
+	dt := time.Date(2024, 5, 15, 15, 30, 45, 123, time.UTC) // Wednesday
+	cases := []struct {
+		unit  TimeUnit
+		start time.Time
+		end   time.Time
+	}{
+		{UnitSecond, time.Date(2024, 5, 15, 15, 30, 45, 0, time.UTC), time.Date(2024, 5, 15, 15, 30, 45, 999999999, time.UTC)},
+		{UnitMinute, time.Date(2024, 5, 15, 15, 30, 0, 0, time.UTC), time.Date(2024, 5, 15, 15, 30, 59, 999999999, time.UTC)},
+		{UnitHour, time.Date(2024, 5, 15, 15, 0, 0, 0, time.UTC), time.Date(2024, 5, 15, 15, 59, 59, 999999999, time.UTC)},
+		{UnitDay, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 15, 23, 59, 59, 999999999, time.UTC)},
+		{UnitWeek, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 19, 23, 59, 59, 999999999, time.UTC)},
+		{UnitMonth, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 31, 23, 59, 59, 999999999, time.UTC)},
+		{UnitQuarter, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 23, 59, 59, 999999999, time.UTC)},
+		{UnitHalfYear, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 23, 59, 59, 999999999, time.UTC)},
+		{UnitYear, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{UnitDecade, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2029, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+	}
+	for _, tc := range cases {
+		if actual := StartOf(dt, tc.unit); !actual.Equal(tc.start) {
+			t.Errorf("Incorrect start of %s, got %s but was expecting %s", tc.unit, actual, tc.start)
+		}
+		if actual := EndOf(dt, tc.unit); !actual.Equal(tc.end) {
+			t.Errorf("Incorrect end of %s, got %s but was expecting %s", tc.unit, actual, tc.end)
+		}
+	}
+
+	if actual := StartOf(dt, TimeUnit(0)); actual != dt {
+		t.Errorf("Incorrect start of an unknown unit, got %s", actual)
+	}
+}
+
+func TestStartOfWeekStart(t *testing.T) {
+	sunday := time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)
+
+	////
+	//// Case 1: ISO weeks start on Monday.
+	////
+
+	if actual, expected := StartOf(sunday, UnitWeek), time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect start of week, got %s but was expecting %s", actual, expected)
+	}
+	if actual, expected := StartOf(sunday, UnitWeek), ISOWeeklyRangeForTime(sunday).Start; !actual.Equal(expected) {
+		t.Errorf("Incorrect start of week, got %s but was expecting the ISO week %s", actual, expected)
+	}
+
+	////
+	//// Case 2: North American weeks start on Sunday.
+	////
+
+	if actual, expected := StartOf(sunday, UnitWeek, WithWeekStart(time.Sunday)), time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect start of week, got %s but was expecting %s", actual, expected)
+	}
+	if actual, expected := EndOf(sunday, UnitWeek, WithWeekStart(time.Sunday)), time.Date(2024, 5, 25, 23, 59, 59, 999999999, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect end of week, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestNextAndPrevious(t *testing.T) {
+	dt := time.Date(2024, 1, 31, 15, 30, 0, 0, time.UTC)
+	cases := []struct {
+		unit     TimeUnit
+		n        int
+		expected time.Time
+	}{
+		{UnitMonth, 1, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{UnitMonth, -1, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{UnitMonth, 0, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{UnitQuarter, 2, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{UnitHalfYear, -1, time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{UnitWeek, 2, time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{UnitDecade, 1, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{UnitHour, 10, time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC)},
+		{UnitMinute, -31, time.Date(2024, 1, 31, 14, 59, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		if actual := Next(dt, tc.unit, tc.n); !actual.Equal(tc.expected) {
+			t.Errorf("Incorrect next %d %s, got %s but was expecting %s", tc.n, tc.unit, actual, tc.expected)
+		}
+		if actual := Previous(dt, tc.unit, -tc.n); !actual.Equal(tc.expected) {
+			t.Errorf("Incorrect previous %d %s, got %s but was expecting %s", -tc.n, tc.unit, actual, tc.expected)
+		}
+	}
+}
+
+func TestTimeUnitDaylightSavingTime(t *testing.T) {
+	toronto, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	////
+	//// Case 1: The 23 hour day ends at the next midnight.
+	////
+
+	dt := time.Date(2024, 3, 10, 12, 0, 0, 0, toronto)
+	start, next := StartOf(dt, UnitDay), Next(dt, UnitDay, 1)
+	if next.Sub(start) != 23*time.Hour || !next.Equal(time.Date(2024, 3, 11, 0, 0, 0, 0, toronto)) {
+		t.Errorf("Incorrect day across spring forward, got %s to %s", start, next)
+	}
+
+	////
+	//// Case 2: The repeated hour starts twice.
+	////
+
+	second := time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC) // 1:30 AM EST
+	if actual := StartOf(second, UnitHour); !actual.Equal(time.Date(2024, 11, 3, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect start of the repeated hour, got %s", actual)
+	}
+
+	////
+	//// Case 3: Skipped midnights start when the gap ends.
+	////
+
+	// Developers Note:
+	// Chile moved its clocks from midnight to 1 AM on September 8th 2024.
+	actual := StartOf(time.Date(2024, 9, 8, 12, 0, 0, 0, santiago), UnitDay)
+	if expected := time.Date(2024, 9, 8, 4, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("Incorrect start of day with a skipped midnight, got %s but was expecting %s", actual, expected)
+	}
+	if actual.Location() != santiago {
+		t.Errorf("Incorrect location, got %s", actual.Location())
+	}
+}
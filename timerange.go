@@ -1,14 +1,167 @@
 package timekit
 
 import (
+	"iter"
 	"time"
 )
 
+// TimeRange represents a span of time which starts at `Start` and runs up to,
+// but not including, `End`. In other words the range is half-open and is
+// written as `[Start, End)`. A range whose `End` is not after its `Start` is
+// considered empty and contains no instants.
+//
+// Every `*RangeForTime` function in this file follows the same convention:
+// the `Start` is the first instant of the period and the `End` is the first
+// instant of the following period. Consecutive periods therefore share their
+// boundary, for example the ISO week of Monday Dec 18th 2023 is returned as
+// `[Mon Dec 18th 00:00, Mon Dec 25th 00:00)`. If you need a closed range then
+// use the `Inclusive` function.
 type TimeRange struct {
 	Start time.Time
 	End   time.Time
 }
 
+// Duration returns the length of the time range. Empty ranges return zero.
+func (tr *TimeRange) Duration() time.Duration {
+	if tr.IsEmpty() {
+		return 0
+	}
+	return tr.End.Sub(tr.Start)
+}
+
+// IsEmpty returns true if the time range does not contain any instants, this
+// happens when the `End` is equal to or before the `Start`.
+func (tr *TimeRange) IsEmpty() bool {
+	return !tr.Start.Before(tr.End)
+}
+
+// Contains returns true if the date/time falls inside the range. Please note
+// the `Start` is included but the `End` is excluded, so for example the
+// 9:00 AM to 10:00 AM range contains 9:00 AM but does not contain 10:00 AM.
+func (tr *TimeRange) Contains(dt time.Time) bool {
+	return !dt.Before(tr.Start) && dt.Before(tr.End)
+}
+
+// ContainsRange returns true if every instant of the other range falls inside
+// this range. An empty range is contained by every range, while a `nil` range
+// is not contained by any range.
+func (tr *TimeRange) ContainsRange(other *TimeRange) bool {
+	if other == nil {
+		return false
+	}
+	if other.IsEmpty() {
+		return true
+	}
+	return !other.Start.Before(tr.Start) && !other.End.After(tr.End)
+}
+
+// Overlaps returns true if the two ranges share at least one instant. Ranges
+// which only touch each other, for example 9-10 AM and 10-11 AM, do not
+// overlap because of the excluded `End`. A `nil` range overlaps nothing.
+func (tr *TimeRange) Overlaps(other *TimeRange) bool {
+	if other == nil || tr.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	return tr.Start.Before(other.End) && other.Start.Before(tr.End)
+}
+
+// Intersect returns the range of instants shared by both ranges or `nil` if
+// the ranges do not overlap.
+func (tr *TimeRange) Intersect(other *TimeRange) *TimeRange {
+	if !tr.Overlaps(other) {
+		return nil
+	}
+	return &TimeRange{
+		Start: laterTime(tr.Start, other.Start),
+		End:   earlierTime(tr.End, other.End),
+	}
+}
+
+// Union returns the ranges which cover every instant found in either range.
+// If the ranges overlap or touch each other then a single merged range is
+// returned, otherwise both ranges are returned sorted by their `Start`. Empty
+// (and `nil`) ranges are dropped from the results.
+func (tr *TimeRange) Union(other *TimeRange) []*TimeRange {
+	if other == nil {
+		other = &TimeRange{}
+	}
+	switch {
+	case tr.IsEmpty() && other.IsEmpty():
+		return []*TimeRange{}
+	case tr.IsEmpty():
+		return []*TimeRange{{Start: other.Start, End: other.End}}
+	case other.IsEmpty():
+		return []*TimeRange{{Start: tr.Start, End: tr.End}}
+	}
+
+	// Developers Note:
+	// Touching ranges (ex: 9-10 AM and 10-11 AM) are merged together since the
+	// result covers the exact same instants as both ranges combined.
+	if !tr.Start.After(other.End) && !other.Start.After(tr.End) {
+		return []*TimeRange{{
+			Start: earlierTime(tr.Start, other.Start),
+			End:   laterTime(tr.End, other.End),
+		}}
+	}
+	if tr.Start.Before(other.Start) {
+		return []*TimeRange{{Start: tr.Start, End: tr.End}, {Start: other.Start, End: other.End}}
+	}
+	return []*TimeRange{{Start: other.Start, End: other.End}, {Start: tr.Start, End: tr.End}}
+}
+
+// Subtract returns the pieces of this range which are not covered by the
+// other range. The result may hold zero pieces (fully covered), one piece
+// (partially covered or not covered at all) or two pieces (the other range
+// sits in the middle). For example subtracting a 12-1 PM lunch from a 9 AM to
+// 5 PM working day returns 9 AM to 12 PM and 1 PM to 5 PM. Subtracting a `nil`
+// range returns this range.
+func (tr *TimeRange) Subtract(other *TimeRange) []*TimeRange {
+	pieces := []*TimeRange{}
+	if tr.IsEmpty() {
+		return pieces
+	}
+	if !tr.Overlaps(other) {
+		return append(pieces, &TimeRange{Start: tr.Start, End: tr.End})
+	}
+	if tr.Start.Before(other.Start) {
+		pieces = append(pieces, &TimeRange{Start: tr.Start, End: other.Start})
+	}
+	if tr.End.After(other.End) {
+		pieces = append(pieces, &TimeRange{Start: other.End, End: tr.End})
+	}
+	return pieces
+}
+
+// Inclusive returns a closed view of the range where the `End` is the last
+// representable instant inside the range (one nanosecond before the half-open
+// `End`). This is useful for databases or APIs which expect `BETWEEN` style
+// inclusive bounds. Empty ranges are returned unchanged.
+func (tr *TimeRange) Inclusive() *TimeRange {
+	if tr.IsEmpty() {
+		return &TimeRange{Start: tr.Start, End: tr.End}
+	}
+	return &TimeRange{
+		Start: tr.Start,
+		End:   tr.End.Add(-time.Nanosecond),
+	}
+}
+
+// earlierTime returns whichever of the two date/times happens first.
+func earlierTime(a time.Time, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// laterTime returns whichever of the two date/times happens last.
+func laterTime(a time.Time, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
 // HourlyRangeForTime function will take a date value and return two
 // date times: (1) The first date time will take the date and discard the
 // minutes, so for example if you give 12:30 PM then it will return 12:00 PM.
@@ -26,8 +179,13 @@ type TimeRange struct {
 // Monday Dec 18th - 5:10 PM --> (1) Monday Dec 18th - 5:00 PM and (2) Monday Dec 18th - 7:00 PM
 // Monday Dec 18th - 10:55 PM --> (1) Monday Dec 18th - 10:00 PM and (2) Monday Dec 18th - 11:00 PM
 func HourlyRangeForTime(dt time.Time) *TimeRange {
-	// Discard the minutes and seconds to get the starting hour
-	startHour := time.Date(dt.Year(), dt.Month(), dt.Day(), dt.Hour(), 0, 0, 0, dt.Location())
+	// Developers Note:
+	// We discard the minutes and seconds by subtracting them from the instant
+	// instead of rebuilding the date with `time.Date`. During the "fall back"
+	// daylight saving transition the 1 AM hour happens twice and `time.Date`
+	// will always pick the first one, so a time in the repeated hour would be
+	// placed into the wrong (earlier) hour.
+	startHour := dt.Add(-(time.Duration(dt.Minute())*time.Minute + time.Duration(dt.Second())*time.Second + time.Duration(dt.Nanosecond())))
 
 	// Add 1 hour to get the ending hour while discarding the minutes
 	endHour := startHour.Add(time.Hour)
@@ -44,35 +202,28 @@ func HourlyRangeForNow(now func() time.Time) *TimeRange {
 	return HourlyRangeForTime(dt)
 }
 
-// HourlyRangesBetweenTimes (TODO: Write detailed description)
+// HourlyRangesBetweenTimes returns the consecutive hour ranges which cover
+// the `[start, end)` range, beginning with the hour that contains `start`.
+// Daylight saving transitions are respected so the repeated "fall back" hour
+// is returned twice (once per offset) and the skipped "spring forward" hour
+// is not returned at all.
 func HourlyRangesBetweenTimes(start time.Time, end time.Time) []*TimeRange {
-	dates := make([]*TimeRange, 0)
-
-	// Developers Note:
-	// We want to leverage our already unit tested code for the `range` functionality so we will use the `TimeStepper`
-	// to iterate through the datetime values and add them to an `results` array.
-	ts := NewTimeStepper(start, end, 0, 0, 0, 1, 0, 0)
-	running := true
-	for running {
-		// Get the value we are on in the timestepper.
-		v := ts.Get()
-
-		dtr := HourlyRangeForTime(v)
-		dates = append(dates, dtr)
-
-		// Run our timestepper to get our next value.
-		ts.Next()
-
-		running = ts.Done() == false
-	}
+	return collectTimeRanges(HourlyRanges(start, end))
+}
 
-	return dates
+// HourlyRanges returns an iterator which lazily yields the same hour ranges as the
+// `HourlyRangesBetweenTimes` function.
+func HourlyRanges(start time.Time, end time.Time) iter.Seq[*TimeRange] {
+	return consecutiveRanges(start, end, HourlyRangeForTime)
 }
 
-// DailyRangeForTime (TODO: Write detailed description)
+// DailyRangeForTime returns the half-open range of the day that the date falls
+// in, starting at midnight of that day and ending at midnight of the next day.
+// Please note that days are not always 24 hours long, on daylight saving
+// transition days the range will be 23 or 25 hours long.
 func DailyRangeForTime(dt time.Time) *TimeRange {
 	startDay := time.Date(dt.Year(), dt.Month(), dt.Day(), 0, 0, 0, 0, dt.Location())
-	endDay := startDay.Add(24 * time.Hour)
+	endDay := time.Date(dt.Year(), dt.Month(), dt.Day()+1, 0, 0, 0, 0, dt.Location())
 
 	return &TimeRange{
 		Start: startDay,
@@ -86,38 +237,27 @@ func DailyRangeForNow(now func() time.Time) *TimeRange {
 	return DailyRangeForTime(dt)
 }
 
-// DailyRangesBetweenTimes (TODO: Write detailed description)
+// DailyRangesBetweenTimes returns the consecutive day ranges which cover the
+// `[start, end)` range, beginning with the day that contains `start`.
 func DailyRangesBetweenTimes(start time.Time, end time.Time) []*TimeRange {
-	dates := make([]*TimeRange, 0)
-
-	// Developers Note:
-	// We want to leverage our already unit tested code for the `range` functionality so we will use the `TimeStepper`
-	// to iterate through the datetime values and add them to an `results` array.
-	ts := NewTimeStepper(start, end, 0, 0, 1, 0, 0, 0)
-	running := true
-	for running {
-		// Get the value we are on in the timestepper.
-		v := ts.Get()
-
-		dtr := DailyRangeForTime(v)
-		dates = append(dates, dtr)
-
-		// Run our timestepper to get our next value.
-		ts.Next()
-
-		running = ts.Done() == false
-	}
+	return collectTimeRanges(DailyRanges(start, end))
+}
 
-	return dates
+// DailyRanges returns an iterator which lazily yields the same day ranges as the
+// `DailyRangesBetweenTimes` function.
+func DailyRanges(start time.Time, end time.Time) iter.Seq[*TimeRange] {
+	return consecutiveRanges(start, end, DailyRangeForTime)
 }
 
-// ISOWeeklyRangeForTime (TODO: Write detailed description)
+// ISOWeeklyRangeForTime returns the half-open range of the ISO week that the
+// date falls in, starting on Monday at midnight and ending on the following
+// Monday at midnight so all of Sunday is included in the week.
 func ISOWeeklyRangeForTime(dt time.Time) *TimeRange {
 	dtFn := func() time.Time {
 		return dt
 	}
 	startISOWeek := FirstDayOfThisISOWeek(dtFn)
-	endISOWeek := LastDayOfThisISOWeek(dtFn)
+	endISOWeek := time.Date(startISOWeek.Year(), startISOWeek.Month(), startISOWeek.Day()+7, 0, 0, 0, 0, startISOWeek.Location())
 
 	return &TimeRange{
 		Start: startISOWeek,
@@ -131,32 +271,21 @@ func ISOWeeklyRangeForNow(now func() time.Time) *TimeRange {
 	return ISOWeeklyRangeForTime(dt)
 }
 
-// ISOWeeklyRangesBetweenTimes (TODO: Write detailed description)
+// ISOWeeklyRangesBetweenTimes returns the consecutive ISO week ranges which cover the
+// `[start, end)` range, beginning with the ISO week that contains `start`.
 func ISOWeeklyRangesBetweenTimes(start time.Time, end time.Time) []*TimeRange {
-	dates := make([]*TimeRange, 0)
-
-	// Developers Note:
-	// We want to leverage our already unit tested code for the `range` functionality so we will use the `TimeStepper`
-	// to iterate through the datetime values and add them to an `results` array.
-	ts := NewTimeStepper(start, end, 0, 0, 7, 0, 0, 0)
-	running := true
-	for running {
-		// Get the value we are on in the timestepper.
-		v := ts.Get()
-
-		dtr := ISOWeeklyRangeForTime(v)
-		dates = append(dates, dtr)
-
-		// Run our timestepper to get our next value.
-		ts.Next()
-
-		running = ts.Done() == false
-	}
+	return collectTimeRanges(ISOWeeklyRanges(start, end))
+}
 
-	return dates
+// ISOWeeklyRanges returns an iterator which lazily yields the same ISO week ranges as the
+// `ISOWeeklyRangesBetweenTimes` function.
+func ISOWeeklyRanges(start time.Time, end time.Time) iter.Seq[*TimeRange] {
+	return consecutiveRanges(start, end, ISOWeeklyRangeForTime)
 }
 
-// MonthlyRangeForTime (TODO: Write detailed description)
+// MonthlyRangeForTime returns the half-open range of the month that the date
+// falls in, starting on the first day of the month at midnight and ending on
+// the first day of the next month at midnight.
 func MonthlyRangeForTime(dt time.Time) *TimeRange {
 	dtFn := func() time.Time {
 		return dt
@@ -176,32 +305,50 @@ func MonthlyRangeForNow(now func() time.Time) *TimeRange {
 	return MonthlyRangeForTime(dt)
 }
 
-// MonthlyRangesBetweenTimes (TODO: Write detailed description)
+// MonthlyRangesBetweenTimes returns the consecutive month ranges which cover the
+// `[start, end)` range, beginning with the month that contains `start`.
 func MonthlyRangesBetweenTimes(start time.Time, end time.Time) []*TimeRange {
-	dates := make([]*TimeRange, 0)
-
-	// Developers Note:
-	// We want to leverage our already unit tested code for the `range` functionality so we will use the `TimeStepper`
-	// to iterate through the datetime values and add them to an `results` array.
-	ts := NewTimeStepper(start, end, 0, 1, 0, 0, 0, 0)
-	running := true
-	for running {
-		// Get the value we are on in the timestepper.
-		v := ts.Get()
-
-		dtr := MonthlyRangeForTime(v)
-		dates = append(dates, dtr)
+	return collectTimeRanges(MonthlyRanges(start, end))
+}
 
-		// Run our timestepper to get our next value.
-		ts.Next()
+// MonthlyRanges returns an iterator which lazily yields the same month ranges as the
+// `MonthlyRangesBetweenTimes` function.
+func MonthlyRanges(start time.Time, end time.Time) iter.Seq[*TimeRange] {
+	return consecutiveRanges(start, end, MonthlyRangeForTime)
+}
 
-		running = ts.Done() == false
+// QuarterlyRangeForTime returns the half-open range of the quarter that the
+// date falls in, starting on the first day of January, April, July or October
+// at midnight and ending on the first day of the next quarter at midnight.
+func QuarterlyRangeForTime(dt time.Time) *TimeRange {
+	return &TimeRange{
+		Start: StartOf(dt, UnitQuarter),
+		End:   Next(dt, UnitQuarter, 1),
 	}
+}
 
-	return dates
+// QuarterlyRangeForNow returns the half-open range of the quarter that the
+// current date falls in.
+func QuarterlyRangeForNow(now func() time.Time) *TimeRange {
+	dt := now()
+	return QuarterlyRangeForTime(dt)
 }
 
-// YearlyRangeForTime (TODO: Write detailed description)
+// QuarterlyRangesBetweenTimes returns the consecutive quarter ranges which cover the
+// `[start, end)` range, beginning with the quarter that contains `start`.
+func QuarterlyRangesBetweenTimes(start time.Time, end time.Time) []*TimeRange {
+	return collectTimeRanges(QuarterlyRanges(start, end))
+}
+
+// QuarterlyRanges returns an iterator which lazily yields the same quarter ranges as the
+// `QuarterlyRangesBetweenTimes` function.
+func QuarterlyRanges(start time.Time, end time.Time) iter.Seq[*TimeRange] {
+	return consecutiveRanges(start, end, QuarterlyRangeForTime)
+}
+
+// YearlyRangeForTime returns the half-open range of the year that the date
+// falls in, starting on January 1st at midnight and ending on January 1st of
+// the next year at midnight.
 func YearlyRangeForTime(dt time.Time) *TimeRange {
 	dtFn := func() time.Time {
 		return dt
@@ -220,27 +367,176 @@ func YearlyRangeForNow(now func() time.Time) *TimeRange {
 	return YearlyRangeForTime(dt)
 }
 
-// YearlyRangesBetweenTimes (TODO: Write detailed description)
+// YearlyRangesBetweenTimes returns the consecutive year ranges which cover the
+// `[start, end)` range, beginning with the year that contains `start`.
 func YearlyRangesBetweenTimes(start time.Time, end time.Time) []*TimeRange {
-	dates := make([]*TimeRange, 0)
+	return collectTimeRanges(YearlyRanges(start, end))
+}
+
+// YearlyRanges returns an iterator which lazily yields the same year ranges as the
+// `YearlyRangesBetweenTimes` function.
+func YearlyRanges(start time.Time, end time.Time) iter.Seq[*TimeRange] {
+	return consecutiveRanges(start, end, YearlyRangeForTime)
+}
+
+// rangeConfig holds the settings of the `RangesBetweenTimes` and `Ranges`
+// functions.
+type rangeConfig struct {
+	clip      bool
+	weekStart time.Weekday
+}
+
+// RangeOption is a function which changes the settings of the
+// `RangesBetweenTimes` and `Ranges` functions.
+type RangeOption func(*rangeConfig)
+
+// WithClipping returns an option which clips the first and last ranges to the
+// `[start, end)` range, so they can be partial. By default the ranges always
+// cover whole units which means the first range can begin before `start` and
+// the last range can end after `end`.
+func WithClipping() RangeOption {
+	return func(cfg *rangeConfig) {
+		cfg.clip = true
+	}
+}
+
+// WithRangeWeekStart returns an option which sets the first day of the week
+// for `UnitWeek` ranges, by default weeks start on Monday.
+func WithRangeWeekStart(weekday time.Weekday) RangeOption {
+	return func(cfg *rangeConfig) {
+		cfg.weekStart = weekday
+	}
+}
+
+// RangesBetweenTimes returns the consecutive ranges of `n` units which cover
+// the `[start, end)` range, for example 15 minute buckets with `UnitMinute`
+// and an `n` of 15 or fortnights with `UnitWeek` and an `n` of 2. The ranges
+// are aligned to the boundaries of the units. Seconds, minutes and hours are
+// counted on the local wall clock from midnight of every day (like the `Floor`
+// function), so 15 minute buckets start on :00, :15, :30 and :45 and 5 hour
+// buckets start at 00:00, 05:00, 10:00, 15:00 and 20:00 with the last one of
+// the day ending early at midnight. Months are counted from January so 2 month
+// ranges start in January, March, May and so on, while days and weeks are
+// counted from January 1st 1970. No ranges are returned if `n` is not greater
+// than zero or the unit is unknown.
+func RangesBetweenTimes(start time.Time, end time.Time, unit TimeUnit, n int, opts ...RangeOption) []*TimeRange {
+	return collectTimeRanges(Ranges(start, end, unit, n, opts...))
+}
+
+// Ranges returns an iterator which lazily yields the same ranges as the
+// `RangesBetweenTimes` function.
+func Ranges(start time.Time, end time.Time, unit TimeUnit, n int, opts ...RangeOption) iter.Seq[*TimeRange] {
+	cfg := rangeConfig{weekStart: time.Monday}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(yield func(*TimeRange) bool) {
+		if _, ok := timeUnitNames[unit]; !ok || n <= 0 {
+			return
+		}
+		rangeForTime := func(dt time.Time) *TimeRange {
+			return unitRangeForTime(dt, unit, n, cfg.weekStart)
+		}
+		for dtr := range consecutiveRanges(start, end, rangeForTime) {
+			if cfg.clip {
+				dtr = dtr.Intersect(&TimeRange{Start: start, End: end})
+				if dtr == nil {
+					return
+				}
+			}
+			if !yield(dtr) {
+				return
+			}
+		}
+	}
+}
+
+// unitRangeForTime returns the half-open range of the `n` units aligned to the
+// unit boundaries which the date/time falls in.
+func unitRangeForTime(dt time.Time, unit TimeUnit, n int, weekStart time.Weekday) *TimeRange {
+	var interval time.Duration
+	switch unit {
+	case UnitSecond:
+		interval = time.Second
+	case UnitMinute:
+		interval = time.Minute
+	case UnitHour:
+		interval = time.Hour
+	}
+	if interval > 0 {
+		start := Floor(dt, time.Duration(n)*interval)
+		return &TimeRange{
+			Start: start,
+			End:   nextAlignedBoundary(start, time.Duration(n)*interval, dt.Location()),
+		}
+	}
 
 	// Developers Note:
-	// We want to leverage our already unit tested code for the `range` functionality so we will use the `TimeStepper`
-	// to iterate through the datetime values and add them to an `results` array.
-	ts := NewTimeStepper(start, end, 1, 0, 0, 0, 0, 0)
-	running := true
-	for running {
-		// Get the value we are on in the timestepper.
-		v := ts.Get()
-
-		dtr := YearlyRangeForTime(v)
-		dates = append(dates, dtr)
+	// We count how many units have passed since a fixed point and step back
+	// to the previous multiple of `n`, the dates are taken from the wall
+	// clock so the count does not depend on the location.
+	opt := WithWeekStart(weekStart)
+	start := StartOf(dt, unit, opt)
+	year, month, day := start.Date()
+	days := int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+	months := year*12 + int(month) - 1
+	var index int
+	switch unit {
+	case UnitDay:
+		index = days
+	case UnitWeek:
+		index = floorDiv(days, 7)
+	case UnitMonth:
+		index = months
+	case UnitQuarter:
+		index = floorDiv(months, 3)
+	case UnitHalfYear:
+		index = floorDiv(months, 6)
+	case UnitYear:
+		index = year
+	case UnitDecade:
+		index = floorDiv(year, 10)
+	}
+	start = Previous(start, unit, index-floorDiv(index, n)*n, opt)
+	return &TimeRange{
+		Start: start,
+		End:   Next(start, unit, n, opt),
+	}
+}
 
-		// Run our timestepper to get our next value.
-		ts.Next()
+// floorDiv returns the quotient of the division rounded towards negative
+// infinity, unlike the `/` operator which rounds towards zero.
+func floorDiv(a int, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
 
-		running = ts.Done() == false
+// consecutiveRanges returns an iterator of the ranges produced by the
+// `rangeForTime` function which cover the `[start, end)` range. The first range
+// is the one containing `start` and every following range begins where the
+// previous one ended, so periods are never skipped even when `start` falls
+// late in its period (ex: stepping one month from Jan 31st).
+func consecutiveRanges(start time.Time, end time.Time, rangeForTime func(time.Time) *TimeRange) iter.Seq[*TimeRange] {
+	return func(yield func(*TimeRange) bool) {
+		dtr := rangeForTime(start)
+		for {
+			if !yield(dtr) || !dtr.End.Before(end) {
+				return
+			}
+			dtr = rangeForTime(dtr.End)
+		}
 	}
+}
 
+// collectTimeRanges returns all the ranges yielded by the iterator. Unlike
+// `slices.Collect` an empty iterator returns an empty (non-nil) slice.
+func collectTimeRanges(seq iter.Seq[*TimeRange]) []*TimeRange {
+	dates := make([]*TimeRange, 0)
+	for dtr := range seq {
+		dates = append(dates, dtr)
+	}
 	return dates
 }
@@ -0,0 +1,123 @@
+package timekit
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeRangeSet is a structure which holds a sorted list of non-overlapping
+// time ranges. Every time a range is added or subtracted the list is kept
+// sorted by `Start` and overlapping or touching ranges are merged together.
+// For example, adding 9 AM to 5 PM and then subtracting a 12-1 PM meeting will
+// leave the free pieces 9 AM to 12 PM and 1 PM to 5 PM.
+type TimeRangeSet struct {
+	ranges []*TimeRange
+}
+
+// NewTimeRangeSet is a constructor of the `TimeRangeSet` struct which adds all
+// the inputted ranges into the set.
+func NewTimeRangeSet(ranges ...*TimeRange) *TimeRangeSet {
+	s := &TimeRangeSet{
+		ranges: []*TimeRange{},
+	}
+	for _, tr := range ranges {
+		s.Add(tr)
+	}
+	return s
+}
+
+// Add inserts the range into the set and merges it with any ranges that it
+// overlaps or touches. Empty ranges are ignored.
+func (s *TimeRangeSet) Add(tr *TimeRange) {
+	if tr == nil || tr.IsEmpty() {
+		return
+	}
+
+	merged := &TimeRange{Start: tr.Start, End: tr.End}
+	results := make([]*TimeRange, 0, len(s.ranges)+1)
+	for _, existing := range s.ranges {
+		// Developers Note:
+		// Ranges which are completely before or after our new range are kept
+		// as is, everything else is absorbed into the merged range.
+		if existing.End.Before(merged.Start) || existing.Start.After(merged.End) {
+			results = append(results, existing)
+			continue
+		}
+		merged.Start = earlierTime(merged.Start, existing.Start)
+		merged.End = laterTime(merged.End, existing.End)
+	}
+	results = append(results, merged)
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Start.Before(results[j].Start)
+	})
+	s.ranges = results
+}
+
+// Subtract removes every instant of the range from the set, splitting any
+// range which the subtracted range sits inside of.
+func (s *TimeRangeSet) Subtract(tr *TimeRange) {
+	if tr == nil || tr.IsEmpty() {
+		return
+	}
+
+	results := make([]*TimeRange, 0, len(s.ranges)+1)
+	for _, existing := range s.ranges {
+		results = append(results, existing.Subtract(tr)...)
+	}
+	s.ranges = results
+}
+
+// Intersect returns a new set holding only the instants of this set which also
+// fall inside the inputted range.
+func (s *TimeRangeSet) Intersect(tr *TimeRange) *TimeRangeSet {
+	results := NewTimeRangeSet()
+	if tr == nil {
+		return results
+	}
+	for _, existing := range s.ranges {
+		if piece := existing.Intersect(tr); piece != nil {
+			results.ranges = append(results.ranges, piece)
+		}
+	}
+	return results
+}
+
+// Contains returns true if the date/time falls inside any range of the set.
+func (s *TimeRangeSet) Contains(dt time.Time) bool {
+	// Developers Note:
+	// Since our ranges are sorted and do not overlap, we can binary search for
+	// the first range that ends after our date/time and check only that one.
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].End.After(dt)
+	})
+	return i < len(s.ranges) && s.ranges[i].Contains(dt)
+}
+
+// Ranges returns a copy of the sorted, merged ranges held by the set.
+func (s *TimeRangeSet) Ranges() []*TimeRange {
+	results := make([]*TimeRange, 0, len(s.ranges))
+	for _, tr := range s.ranges {
+		results = append(results, &TimeRange{Start: tr.Start, End: tr.End})
+	}
+	return results
+}
+
+// Len returns the number of separate ranges held by the set.
+func (s *TimeRangeSet) Len() int {
+	return len(s.ranges)
+}
+
+// IsEmpty returns true if the set does not contain any instants.
+func (s *TimeRangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Duration returns the total length of all the ranges in the set.
+func (s *TimeRangeSet) Duration() time.Duration {
+	var total time.Duration
+	for _, tr := range s.ranges {
+		total += tr.Duration()
+	}
+	return total
+}
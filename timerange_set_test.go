@@ -0,0 +1,80 @@
+package timekit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeRangeSetAdd(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	s := NewTimeRangeSet(
+		&TimeRange{Start: time.Date(2023, 12, 18, 13, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 14, 0, 0, 0, loc)}, // 1-2 PM
+		&TimeRange{Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 10, 0, 0, 0, loc)},  // 9-10 AM
+		&TimeRange{Start: time.Date(2023, 12, 18, 10, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 11, 0, 0, 0, loc)}, // 10-11 AM
+	)
+	expected := []*TimeRange{
+		{Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 11, 0, 0, 0, loc)},
+		{Start: time.Date(2023, 12, 18, 13, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 14, 0, 0, 0, loc)},
+	}
+	if actual := s.Ranges(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+
+	// Adding a range which bridges both ranges should merge everything.
+	s.Add(&TimeRange{Start: time.Date(2023, 12, 18, 10, 30, 0, 0, loc), End: time.Date(2023, 12, 18, 13, 30, 0, 0, loc)})
+	expected = []*TimeRange{
+		{Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 14, 0, 0, 0, loc)},
+	}
+	if actual := s.Ranges(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+	if s.Duration() != 5*time.Hour {
+		t.Errorf("Incorrect duration, got %v but was expecting %v", s.Duration(), 5*time.Hour)
+	}
+}
+
+func TestTimeRangeSetSubtract(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	workday := &TimeRange{
+		Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc),  // Monday Dec 18th - 9 AM
+		End:   time.Date(2023, 12, 18, 17, 0, 0, 0, loc), // Monday Dec 18th - 5 PM
+	}
+	s := NewTimeRangeSet(workday)
+	s.Subtract(&TimeRange{Start: time.Date(2023, 12, 18, 10, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 11, 0, 0, 0, loc)}) // Standup
+	s.Subtract(&TimeRange{Start: time.Date(2023, 12, 18, 16, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 18, 0, 0, 0, loc)}) // Runs past 5 PM
+
+	expected := []*TimeRange{
+		{Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 10, 0, 0, 0, loc)},
+		{Start: time.Date(2023, 12, 18, 11, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 16, 0, 0, 0, loc)},
+	}
+	if actual := s.Ranges(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+
+	if s.Contains(time.Date(2023, 12, 18, 10, 30, 0, 0, loc)) {
+		t.Errorf("Incorrect value, 10:30 AM should not be free")
+	}
+	if !s.Contains(time.Date(2023, 12, 18, 11, 0, 0, 0, loc)) {
+		t.Errorf("Incorrect value, 11:00 AM should be free")
+	}
+	if s.Contains(time.Date(2023, 12, 18, 16, 0, 0, 0, loc)) {
+		t.Errorf("Incorrect value, 4:00 PM should not be free")
+	}
+}
+
+func TestTimeRangeSetIntersect(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	s := NewTimeRangeSet(
+		&TimeRange{Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 11, 0, 0, 0, loc)},
+		&TimeRange{Start: time.Date(2023, 12, 18, 13, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 15, 0, 0, 0, loc)},
+	)
+	actual := s.Intersect(&TimeRange{Start: time.Date(2023, 12, 18, 10, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 14, 0, 0, 0, loc)})
+	expected := []*TimeRange{
+		{Start: time.Date(2023, 12, 18, 10, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 11, 0, 0, 0, loc)},
+		{Start: time.Date(2023, 12, 18, 13, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 14, 0, 0, 0, loc)},
+	}
+	if !reflect.DeepEqual(actual.Ranges(), expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual.Ranges(), expected)
+	}
+}
@@ -1,6 +1,7 @@
 package timekit
 
 import (
+	"reflect"
 	"testing"
 	"time"
 )
@@ -164,7 +165,7 @@ func TestISOWeeklyRangeForTime(t *testing.T) {
 	given := time.Date(2023, 12, 18, 9, 30, 0, 0, loc) // Monday Dec 18th - 9:30 AM
 	dtr := ISOWeeklyRangeForTime(given)
 	exp1 := time.Date(2023, 12, 18, 0, 0, 0, 0, loc) // Monday Dec 18th - 12 AM
-	exp2 := time.Date(2023, 12, 24, 0, 0, 0, 0, loc) // Monday Dec 24th - 12 AM
+	exp2 := time.Date(2023, 12, 25, 0, 0, 0, 0, loc) // Monday Dec 25th - 12 AM
 	if exp1 != dtr.Start {
 		t.Errorf("Incorrect date, got %s but was expecting %s", dtr.Start, exp1)
 	}
@@ -183,7 +184,7 @@ func TestISOWeeklyRangeForNow(t *testing.T) {
 
 	dtr := ISOWeeklyRangeForNow(timeFn)
 	exp1 := time.Date(2023, 12, 18, 0, 0, 0, 0, loc) // Monday Dec 18th - 12 AM
-	exp2 := time.Date(2023, 12, 24, 0, 0, 0, 0, loc) // Monday Dec 24th - 12 AM
+	exp2 := time.Date(2023, 12, 25, 0, 0, 0, 0, loc) // Monday Dec 25th - 12 AM
 	if exp1 != dtr.Start {
 		t.Errorf("Incorrect date, got %s but was expecting %s", dtr.Start, exp1)
 	}
@@ -204,7 +205,7 @@ func TestISOWeeklyRangesBetweenTimes(t *testing.T) {
 		switch i {
 		case 0:
 			exp1 := time.Date(2023, 12, 18, 0, 0, 0, 0, loc) // Monday Dec 18th - 12 AM
-			exp2 := time.Date(2023, 12, 24, 0, 0, 0, 0, loc) // Monday Dec 24th - 12 AM
+			exp2 := time.Date(2023, 12, 25, 0, 0, 0, 0, loc) // Monday Dec 25th - 12 AM
 			if exp1 != dtr.Start {
 				t.Errorf("Incorrect date, got %s but was expecting %s", dtr.Start, exp1)
 			}
@@ -214,7 +215,7 @@ func TestISOWeeklyRangesBetweenTimes(t *testing.T) {
 			break
 		case 1:
 			exp1 := time.Date(2023, 12, 25, 0, 0, 0, 0, loc)
-			exp2 := time.Date(2023, 12, 31, 0, 0, 0, 0, loc)
+			exp2 := time.Date(2024, 01, 01, 0, 0, 0, 0, loc)
 			if exp1 != dtr.Start {
 				t.Errorf("Incorrect date, got %s but was expecting %s", dtr.Start, exp1)
 			}
@@ -224,7 +225,7 @@ func TestISOWeeklyRangesBetweenTimes(t *testing.T) {
 			break
 		case 2:
 			exp1 := time.Date(2024, 01, 01, 0, 0, 0, 0, loc)
-			exp2 := time.Date(2024, 01, 07, 0, 0, 0, 0, loc)
+			exp2 := time.Date(2024, 01, 8, 0, 0, 0, 0, loc)
 			if exp1 != dtr.Start {
 				t.Errorf("Incorrect date, got %s but was expecting %s", dtr.Start, exp1)
 			}
@@ -300,8 +301,8 @@ func TestMonthlyRangesBetweenTimes(t *testing.T) {
 			}
 			break
 		case 2:
-			exp1 := time.Date(2024, 12, 18, 11, 0, 0, 0, loc) // Monday Dec 18th - 11 AM
-			exp2 := time.Date(2024, 12, 18, 12, 0, 0, 0, loc) // Monday Dec 18th - 12 AM
+			exp1 := time.Date(2024, 02, 01, 0, 0, 0, 0, loc)
+			exp2 := time.Date(2024, 03, 01, 0, 0, 0, 0, loc)
 			if exp1 != dtr.Start {
 				t.Errorf("Incorrect date, got %s but was expecting %s", dtr.Start, exp1)
 			}
@@ -389,3 +390,503 @@ func TestYearlyRangesBetweenTimes(t *testing.T) {
 		}
 	}
 }
+
+func TestTimeRangeDurationAndIsEmpty(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	tr := &TimeRange{
+		Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc),  // Monday Dec 18th - 9 AM
+		End:   time.Date(2023, 12, 18, 17, 0, 0, 0, loc), // Monday Dec 18th - 5 PM
+	}
+	if tr.Duration() != 8*time.Hour {
+		t.Errorf("Incorrect duration, got %v but was expecting %v", tr.Duration(), 8*time.Hour)
+	}
+	if tr.IsEmpty() {
+		t.Errorf("Incorrect value, got %v but was expecting %v", true, false)
+	}
+
+	empty := &TimeRange{Start: tr.End, End: tr.Start}
+	if empty.Duration() != 0 {
+		t.Errorf("Incorrect duration, got %v but was expecting %v", empty.Duration(), 0)
+	}
+	if !empty.IsEmpty() {
+		t.Errorf("Incorrect value, got %v but was expecting %v", false, true)
+	}
+}
+
+func TestTimeRangeContains(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	tr := &TimeRange{
+		Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc),  // Monday Dec 18th - 9 AM
+		End:   time.Date(2023, 12, 18, 10, 0, 0, 0, loc), // Monday Dec 18th - 10 AM
+	}
+	if !tr.Contains(tr.Start) {
+		t.Errorf("Incorrect value, start %s should be contained", tr.Start)
+	}
+	if tr.Contains(tr.End) {
+		t.Errorf("Incorrect value, end %s should not be contained", tr.End)
+	}
+	if !tr.Contains(tr.End.Add(-time.Nanosecond)) {
+		t.Errorf("Incorrect value, last instant before %s should be contained", tr.End)
+	}
+
+	inner := &TimeRange{Start: tr.Start.Add(15 * time.Minute), End: tr.End}
+	if !tr.ContainsRange(inner) {
+		t.Errorf("Incorrect value, %v should contain %v", tr, inner)
+	}
+	outer := &TimeRange{Start: tr.Start, End: tr.End.Add(time.Minute)}
+	if tr.ContainsRange(outer) {
+		t.Errorf("Incorrect value, %v should not contain %v", tr, outer)
+	}
+}
+
+func TestTimeRangeOverlapsAndIntersect(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	a := &TimeRange{
+		Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc),  // Monday Dec 18th - 9 AM
+		End:   time.Date(2023, 12, 18, 11, 0, 0, 0, loc), // Monday Dec 18th - 11 AM
+	}
+	b := &TimeRange{
+		Start: time.Date(2023, 12, 18, 10, 0, 0, 0, loc), // Monday Dec 18th - 10 AM
+		End:   time.Date(2023, 12, 18, 12, 0, 0, 0, loc), // Monday Dec 18th - 12 PM
+	}
+	if !a.Overlaps(b) || !b.Overlaps(a) {
+		t.Errorf("Incorrect value, %v and %v should overlap", a, b)
+	}
+	actual := a.Intersect(b)
+	expected := &TimeRange{Start: b.Start, End: a.End}
+	if actual == nil || actual.Start != expected.Start || actual.End != expected.End {
+		t.Errorf("Incorrect range, got %v but was expecting %v", actual, expected)
+	}
+
+	// Touching ranges do not overlap because the end is excluded.
+	c := &TimeRange{Start: a.End, End: a.End.Add(time.Hour)}
+	if a.Overlaps(c) {
+		t.Errorf("Incorrect value, %v and %v should not overlap", a, c)
+	}
+	if a.Intersect(c) != nil {
+		t.Errorf("Incorrect range, got %v but was expecting nil", a.Intersect(c))
+	}
+}
+
+func TestTimeRangeUnion(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	a := &TimeRange{
+		Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc),  // Monday Dec 18th - 9 AM
+		End:   time.Date(2023, 12, 18, 10, 0, 0, 0, loc), // Monday Dec 18th - 10 AM
+	}
+	b := &TimeRange{
+		Start: time.Date(2023, 12, 18, 10, 0, 0, 0, loc), // Monday Dec 18th - 10 AM
+		End:   time.Date(2023, 12, 18, 11, 0, 0, 0, loc), // Monday Dec 18th - 11 AM
+	}
+	actual := b.Union(a)
+	if len(actual) != 1 || actual[0].Start != a.Start || actual[0].End != b.End {
+		t.Errorf("Incorrect ranges, got %v but was expecting a single merged range", actual)
+	}
+
+	c := &TimeRange{
+		Start: time.Date(2023, 12, 18, 13, 0, 0, 0, loc), // Monday Dec 18th - 1 PM
+		End:   time.Date(2023, 12, 18, 14, 0, 0, 0, loc), // Monday Dec 18th - 2 PM
+	}
+	actual = c.Union(a)
+	if len(actual) != 2 || actual[0].Start != a.Start || actual[1].Start != c.Start {
+		t.Errorf("Incorrect ranges, got %v but was expecting two sorted ranges", actual)
+	}
+}
+
+func TestTimeRangeSubtract(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	day := &TimeRange{
+		Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc),  // Monday Dec 18th - 9 AM
+		End:   time.Date(2023, 12, 18, 17, 0, 0, 0, loc), // Monday Dec 18th - 5 PM
+	}
+	lunch := &TimeRange{
+		Start: time.Date(2023, 12, 18, 12, 0, 0, 0, loc), // Monday Dec 18th - 12 PM
+		End:   time.Date(2023, 12, 18, 13, 0, 0, 0, loc), // Monday Dec 18th - 1 PM
+	}
+	actual := day.Subtract(lunch)
+	expected := []*TimeRange{
+		{Start: day.Start, End: lunch.Start},
+		{Start: lunch.End, End: day.End},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+
+	// Subtracting a covering range leaves nothing behind.
+	actual = lunch.Subtract(day)
+	if len(actual) != 0 {
+		t.Errorf("Incorrect ranges, got %v but was expecting none", actual)
+	}
+
+	// Subtracting a range that does not overlap leaves the range untouched.
+	evening := &TimeRange{Start: day.End, End: day.End.Add(time.Hour)}
+	actual = day.Subtract(evening)
+	if len(actual) != 1 || actual[0].Start != day.Start || actual[0].End != day.End {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, day)
+	}
+}
+
+func TestTimeRangeNilOther(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+	tr := &TimeRange{
+		Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc),  // Monday Dec 18th - 9 AM
+		End:   time.Date(2023, 12, 18, 10, 0, 0, 0, loc), // Monday Dec 18th - 10 AM
+	}
+
+	// A nil range is treated as empty and must never panic.
+	cases := []struct {
+		name     string
+		actual   any
+		expected any
+	}{
+		{"ContainsRange", tr.ContainsRange(nil), false},
+		{"Overlaps", tr.Overlaps(nil), false},
+		{"Intersect", tr.Intersect(nil), (*TimeRange)(nil)},
+		{"Union", tr.Union(nil), []*TimeRange{{Start: tr.Start, End: tr.End}}},
+		{"Subtract", tr.Subtract(nil), []*TimeRange{{Start: tr.Start, End: tr.End}}},
+	}
+	for _, tc := range cases {
+		if !reflect.DeepEqual(tc.actual, tc.expected) {
+			t.Errorf("Incorrect %s result, got %v but was expecting %v", tc.name, tc.actual, tc.expected)
+		}
+	}
+}
+
+func TestRangeForTimeHalfOpenBoundaries(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Toronto")
+	families := []struct {
+		name  string
+		fn    func(time.Time) *TimeRange
+		given time.Time
+		start time.Time
+		end   time.Time
+	}{
+		{"hourly", HourlyRangeForTime, time.Date(2023, 12, 18, 9, 30, 0, 0, loc), time.Date(2023, 12, 18, 9, 0, 0, 0, loc), time.Date(2023, 12, 18, 10, 0, 0, 0, loc)},
+		{"daily", DailyRangeForTime, time.Date(2023, 12, 18, 9, 30, 0, 0, loc), time.Date(2023, 12, 18, 0, 0, 0, 0, loc), time.Date(2023, 12, 19, 0, 0, 0, 0, loc)},
+		{"weekly", ISOWeeklyRangeForTime, time.Date(2023, 12, 24, 23, 59, 0, 0, loc), time.Date(2023, 12, 18, 0, 0, 0, 0, loc), time.Date(2023, 12, 25, 0, 0, 0, 0, loc)}, // Sunday belongs to the week.
+		{"monthly", MonthlyRangeForTime, time.Date(2024, 2, 29, 12, 0, 0, 0, loc), time.Date(2024, 2, 1, 0, 0, 0, 0, loc), time.Date(2024, 3, 1, 0, 0, 0, 0, loc)},
+		{"yearly", YearlyRangeForTime, time.Date(2023, 12, 31, 23, 0, 0, 0, loc), time.Date(2023, 1, 1, 0, 0, 0, 0, loc), time.Date(2024, 1, 1, 0, 0, 0, 0, loc)},
+	}
+	for _, f := range families {
+		dtr := f.fn(f.given)
+		if !dtr.Start.Equal(f.start) || !dtr.End.Equal(f.end) {
+			t.Errorf("Incorrect %s range, got [%s, %s) but was expecting [%s, %s)", f.name, dtr.Start, dtr.End, f.start, f.end)
+		}
+
+		// The first instant belongs to the range.
+		if atStart := f.fn(f.start); !atStart.Start.Equal(f.start) {
+			t.Errorf("Incorrect %s range, start %s should belong to its own range but got %s", f.name, f.start, atStart.Start)
+		}
+
+		// The last representable instant belongs to the range.
+		last := f.end.Add(-time.Nanosecond)
+		if atLast := f.fn(last); !atLast.Start.Equal(f.start) || !atLast.End.Equal(f.end) {
+			t.Errorf("Incorrect %s range, %s should belong to [%s, %s)", f.name, last, f.start, f.end)
+		}
+
+		// The end belongs to the next range which starts where this one ends.
+		if next := f.fn(f.end); !next.Start.Equal(f.end) {
+			t.Errorf("Incorrect %s range, next range should start at %s but got %s", f.name, f.end, next.Start)
+		}
+
+		// Our closed view ends on the last representable instant.
+		if incl := dtr.Inclusive(); !incl.End.Equal(last) || !incl.Start.Equal(f.start) {
+			t.Errorf("Incorrect %s inclusive range, got [%s, %s] but was expecting [%s, %s]", f.name, incl.Start, incl.End, f.start, last)
+		}
+	}
+}
+
+func TestRangesBetweenTimesAreContiguous(t *testing.T) {
+	loc := time.UTC                                    // closure can be used if necessary
+	start := time.Date(2023, 11, 18, 9, 30, 0, 0, loc) // Saturday Nov 18th - 9:30 AM
+	end := time.Date(2025, 2, 3, 11, 30, 0, 0, loc)    // Monday Feb 3rd - 11:30 AM
+
+	families := map[string][]*TimeRange{
+		"weekly":    ISOWeeklyRangesBetweenTimes(start, end),
+		"monthly":   MonthlyRangesBetweenTimes(start, end),
+		"quarterly": QuarterlyRangesBetweenTimes(start, end),
+		"yearly":    YearlyRangesBetweenTimes(start, end),
+	}
+	for name, dtrdtr := range families {
+		if !dtrdtr[0].Contains(start) {
+			t.Errorf("Incorrect %s ranges, first range %v should contain %s", name, dtrdtr[0], start)
+		}
+		if last := dtrdtr[len(dtrdtr)-1]; !last.Contains(end) {
+			t.Errorf("Incorrect %s ranges, last range %v should contain %s", name, last, end)
+		}
+		for i := 1; i < len(dtrdtr); i++ {
+			if !dtrdtr[i-1].End.Equal(dtrdtr[i].Start) {
+				t.Errorf("Incorrect %s ranges, range %d ends at %s but range %d starts at %s", name, i-1, dtrdtr[i-1].End, i, dtrdtr[i].Start)
+			}
+		}
+	}
+}
+
+func TestDailyRangeForTimeDaylightSaving(t *testing.T) {
+	tests := []struct {
+		zone   string
+		year   int
+		month  time.Month
+		day    int
+		length time.Duration
+	}{
+		{"America/Toronto", 2023, time.March, 12, 23 * time.Hour},   // Spring forward
+		{"America/Toronto", 2023, time.November, 5, 25 * time.Hour}, // Fall back
+		{"Europe/London", 2023, time.March, 26, 23 * time.Hour},
+		{"Europe/London", 2023, time.October, 29, 25 * time.Hour},
+		{"Australia/Sydney", 2023, time.April, 2, 25 * time.Hour},
+		{"Australia/Sydney", 2023, time.October, 1, 23 * time.Hour},
+		{"Asia/Kolkata", 2023, time.March, 12, 24 * time.Hour}, // No daylight saving
+		{"UTC", 2023, time.March, 12, 24 * time.Hour},
+	}
+	for _, tc := range tests {
+		loc, err := time.LoadLocation(tc.zone)
+		if err != nil {
+			t.Fatalf("Failed loading location %s: %v", tc.zone, err)
+		}
+		given := time.Date(tc.year, tc.month, tc.day, 12, 0, 0, 0, loc)
+		dtr := DailyRangeForTime(given)
+
+		exp1 := time.Date(tc.year, tc.month, tc.day, 0, 0, 0, 0, loc)
+		exp2 := time.Date(tc.year, tc.month, tc.day+1, 0, 0, 0, 0, loc)
+		if !exp1.Equal(dtr.Start) {
+			t.Errorf("Incorrect date for %s, got %s but was expecting %s", tc.zone, dtr.Start, exp1)
+		}
+		if !exp2.Equal(dtr.End) {
+			t.Errorf("Incorrect date for %s, got %s but was expecting %s", tc.zone, dtr.End, exp2)
+		}
+		if dtr.Duration() != tc.length {
+			t.Errorf("Incorrect duration for %s, got %v but was expecting %v", tc.zone, dtr.Duration(), tc.length)
+		}
+	}
+}
+
+func TestHourlyRangesBetweenTimesDaylightSaving(t *testing.T) {
+	tests := []struct {
+		zone  string
+		year  int
+		month time.Month
+		day   int
+		hours int
+	}{
+		{"America/Toronto", 2023, time.March, 12, 23},   // Spring forward, no 2 AM hour.
+		{"America/Toronto", 2023, time.November, 5, 25}, // Fall back, 1 AM hour happens twice.
+		{"Europe/London", 2023, time.March, 26, 23},
+		{"Europe/London", 2023, time.October, 29, 25},
+		{"Australia/Sydney", 2023, time.April, 2, 25},
+		{"Australia/Sydney", 2023, time.October, 1, 23},
+		{"Asia/Kolkata", 2023, time.March, 12, 24}, // No daylight saving but a half hour offset.
+	}
+	for _, tc := range tests {
+		loc, err := time.LoadLocation(tc.zone)
+		if err != nil {
+			t.Fatalf("Failed loading location %s: %v", tc.zone, err)
+		}
+		day := DailyRangeForTime(time.Date(tc.year, tc.month, tc.day, 12, 0, 0, 0, loc))
+		dtrdtr := HourlyRangesBetweenTimes(day.Start, day.End)
+		if len(dtrdtr) != tc.hours {
+			t.Errorf("Incorrect number of hours for %s, got %d but was expecting %d", tc.zone, len(dtrdtr), tc.hours)
+			continue
+		}
+		for i, dtr := range dtrdtr {
+			if dtr.Duration() != time.Hour {
+				t.Errorf("Incorrect duration for %s hour %d, got %v", tc.zone, i, dtr.Duration())
+			}
+			if dtr.Start.Minute() != 0 || dtr.Start.Second() != 0 {
+				t.Errorf("Incorrect hour start for %s hour %d, got %s", tc.zone, i, dtr.Start)
+			}
+			if i > 0 && !dtrdtr[i-1].End.Equal(dtr.Start) {
+				t.Errorf("Incorrect ranges for %s, hour %d ends at %s but hour %d starts at %s", tc.zone, i-1, dtrdtr[i-1].End, i, dtr.Start)
+			}
+		}
+		if !dtrdtr[0].Start.Equal(day.Start) || !dtrdtr[len(dtrdtr)-1].End.Equal(day.End) {
+			t.Errorf("Incorrect ranges for %s, hours should cover %v", tc.zone, day)
+		}
+	}
+}
+
+func TestHourlyRangeForTimeRepeatedHour(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Toronto")
+	firstOneAM := time.Date(2023, 11, 5, 1, 30, 0, 0, loc) // 1:30 AM EDT
+	secondOneAM := firstOneAM.Add(time.Hour)               // 1:30 AM EST
+
+	first := HourlyRangeForTime(firstOneAM)
+	second := HourlyRangeForTime(secondOneAM)
+	if !first.End.Equal(second.Start) {
+		t.Errorf("Incorrect ranges, first 1 AM hour ends at %s but second 1 AM hour starts at %s", first.End, second.Start)
+	}
+	if !second.Contains(secondOneAM) {
+		t.Errorf("Incorrect range, %v should contain %s", second, secondOneAM)
+	}
+}
+
+func TestDailyRanges(t *testing.T) {
+	loc := time.UTC                                    // closure can be used if necessary
+	start := time.Date(2023, 12, 18, 9, 30, 0, 0, loc) // Monday Dec 18th - 9:30 AM
+	end := time.Date(2028, 12, 18, 9, 30, 0, 0, loc)   // Monday Dec 18th 2028 - 9:30 AM
+
+	var actual []*TimeRange
+	for dtr := range DailyRanges(start, end) {
+		actual = append(actual, dtr)
+		if len(actual) == 2 {
+			break
+		}
+	}
+	expected := []*TimeRange{
+		{Start: time.Date(2023, 12, 18, 0, 0, 0, 0, loc), End: time.Date(2023, 12, 19, 0, 0, 0, 0, loc)},
+		{Start: time.Date(2023, 12, 19, 0, 0, 0, 0, loc), End: time.Date(2023, 12, 20, 0, 0, 0, 0, loc)},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+}
+
+func TestQuarterlyRangeForTime(t *testing.T) {
+	loc := time.UTC                                    // closure can be used if necessary
+	given := time.Date(2023, 12, 18, 9, 30, 0, 0, loc) // Monday Dec 18th - 9:30 AM
+	dtr := QuarterlyRangeForTime(given)
+	exp1 := time.Date(2023, 10, 01, 0, 0, 0, 0, loc)
+	exp2 := time.Date(2024, 01, 01, 0, 0, 0, 0, loc)
+	if exp1 != dtr.Start {
+		t.Errorf("Incorrect date, got %s but was expecting %s", dtr.Start, exp1)
+	}
+	if exp2 != dtr.End {
+		t.Errorf("Incorrect date, got %s but was expecting %s", dtr.End, exp2)
+	}
+}
+
+func TestQuarterlyRangesBetweenTimes(t *testing.T) {
+	loc := time.UTC                                    // closure can be used if necessary
+	start := time.Date(2023, 12, 18, 9, 30, 0, 0, loc) // Monday Dec 18th - 9:30 AM
+	end := time.Date(2024, 04, 01, 0, 0, 0, 0, loc)
+
+	actual := QuarterlyRangesBetweenTimes(start, end)
+	expected := []*TimeRange{
+		{Start: time.Date(2023, 10, 1, 0, 0, 0, 0, loc), End: time.Date(2024, 1, 1, 0, 0, 0, 0, loc)},
+		{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, loc), End: time.Date(2024, 4, 1, 0, 0, 0, 0, loc)},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+}
+
+func TestRangesBetweenTimes(t *testing.T) {
+	loc := time.UTC // closure can be used if necessary
+
+	////
+	//// Case 1: 15 minute buckets are aligned to the quarter hours.
+	////
+
+	start := time.Date(2023, 12, 18, 9, 7, 0, 0, loc)
+	end := time.Date(2023, 12, 18, 9, 40, 0, 0, loc)
+	actual := RangesBetweenTimes(start, end, UnitMinute, 15)
+	expected := []*TimeRange{
+		{Start: time.Date(2023, 12, 18, 9, 0, 0, 0, loc), End: time.Date(2023, 12, 18, 9, 15, 0, 0, loc)},
+		{Start: time.Date(2023, 12, 18, 9, 15, 0, 0, loc), End: time.Date(2023, 12, 18, 9, 30, 0, 0, loc)},
+		{Start: time.Date(2023, 12, 18, 9, 30, 0, 0, loc), End: time.Date(2023, 12, 18, 9, 45, 0, 0, loc)},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+
+	////
+	//// Case 2: Clipping makes the first and last buckets partial.
+	////
+
+	actual = RangesBetweenTimes(start, end, UnitMinute, 15, WithClipping())
+	expected[0].Start = start
+	expected[2].End = end
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+
+	////
+	//// Case 3: Hour buckets restart at midnight.
+	////
+
+	start = time.Date(2023, 12, 18, 21, 0, 0, 0, loc)
+	end = time.Date(2023, 12, 19, 6, 0, 0, 0, loc)
+	actual = RangesBetweenTimes(start, end, UnitHour, 5)
+	expected = []*TimeRange{
+		{Start: time.Date(2023, 12, 18, 20, 0, 0, 0, loc), End: time.Date(2023, 12, 19, 0, 0, 0, 0, loc)},
+		{Start: time.Date(2023, 12, 19, 0, 0, 0, 0, loc), End: time.Date(2023, 12, 19, 5, 0, 0, 0, loc)},
+		{Start: time.Date(2023, 12, 19, 5, 0, 0, 0, loc), End: time.Date(2023, 12, 19, 10, 0, 0, 0, loc)},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+
+	////
+	//// Case 4: Fortnights always start on the same weeks.
+	////
+
+	start = time.Date(2024, 1, 10, 12, 0, 0, 0, loc) // Wednesday Jan 10th
+	end = time.Date(2024, 1, 20, 0, 0, 0, 0, loc)
+	actual = RangesBetweenTimes(start, end, UnitWeek, 2)
+	expected = []*TimeRange{
+		{Start: time.Date(2024, 1, 8, 0, 0, 0, 0, loc), End: time.Date(2024, 1, 22, 0, 0, 0, 0, loc)},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+	later := RangesBetweenTimes(start.AddDate(0, 0, 7), end.AddDate(0, 0, 7), UnitWeek, 2)
+	if !later[0].Start.Equal(expected[0].Start) {
+		t.Errorf("Incorrect fortnight, got %v but was expecting it to start on %s", later[0], expected[0].Start)
+	}
+	sunday := RangesBetweenTimes(start, end, UnitWeek, 1, WithRangeWeekStart(time.Sunday))
+	if exp := time.Date(2024, 1, 7, 0, 0, 0, 0, loc); !sunday[0].Start.Equal(exp) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", sunday[0].Start, exp)
+	}
+
+	////
+	//// Case 5: Half-years start in January and July.
+	////
+
+	start = time.Date(2023, 3, 18, 9, 30, 0, 0, loc)
+	end = time.Date(2024, 2, 1, 0, 0, 0, 0, loc)
+	actual = RangesBetweenTimes(start, end, UnitHalfYear, 1)
+	expected = []*TimeRange{
+		{Start: time.Date(2023, 1, 1, 0, 0, 0, 0, loc), End: time.Date(2023, 7, 1, 0, 0, 0, 0, loc)},
+		{Start: time.Date(2023, 7, 1, 0, 0, 0, 0, loc), End: time.Date(2024, 1, 1, 0, 0, 0, 0, loc)},
+		{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, loc), End: time.Date(2024, 7, 1, 0, 0, 0, 0, loc)},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+	if actual := RangesBetweenTimes(start, end, UnitMonth, 6); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Incorrect ranges, got %v but was expecting %v", actual, expected)
+	}
+
+	////
+	//// Case 6: Invalid inputs return no ranges.
+	////
+
+	if actual := RangesBetweenTimes(start, end, UnitDay, 0); len(actual) != 0 {
+		t.Errorf("Incorrect ranges, got %v but was expecting none", actual)
+	}
+	if actual := RangesBetweenTimes(start, end, TimeUnit(0), 1); len(actual) != 0 {
+		t.Errorf("Incorrect ranges, got %v but was expecting none", actual)
+	}
+}
+
+func TestRangesBetweenTimesDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 3, 9, 12, 0, 0, 0, loc)
+	end := time.Date(2024, 3, 11, 12, 0, 0, 0, loc)
+
+	actual := RangesBetweenTimes(start, end, UnitDay, 1)
+	if len(actual) != 3 {
+		t.Fatalf("Incorrect ranges, got %v", actual)
+	}
+	if d := actual[1].Duration(); d != 23*time.Hour {
+		t.Errorf("Incorrect duration, got %s but was expecting 23h", d)
+	}
+	for i, dtr := range actual {
+		if expected := DailyRangeForTime(dtr.Start); !dtr.Start.Equal(expected.Start) || !dtr.End.Equal(expected.End) {
+			t.Errorf("Incorrect range %d, got %v but was expecting %v", i, dtr, expected)
+		}
+	}
+}
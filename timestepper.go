@@ -1,26 +1,122 @@
 package timekit
 
 import (
+	"iter"
+	"slices"
 	"time"
 )
 
+// StepMode controls how the `TimeStepper` applies its steps when the location
+// observes daylight saving time.
+type StepMode int
+
+const (
+	// AbsoluteStepMode applies the steps to the absolute instant (in UTC) so
+	// every hour, minute and second step is exactly that long. This means a
+	// daily step at 9 AM will be at 8 AM or 10 AM after a daylight saving
+	// transition. This is the default mode.
+	AbsoluteStepMode StepMode = iota
+
+	// WallClockStepMode applies the steps to the local date and time of day
+	// so a daily step at 9 AM stays at 9 AM all year round.
+	WallClockStepMode
+)
+
+// NonexistentTimePolicy controls what the `WallClockStepMode` does when a step
+// lands on a local time which does not exist, for example 2:30 AM on the day
+// clocks "spring forward" from 2 AM to 3 AM.
+type NonexistentTimePolicy int
+
+const (
+	// ShiftForwardNonexistentTime moves the time forward by the length of the
+	// gap, for example 2:30 AM becomes 3:30 AM. This is the default policy.
+	ShiftForwardNonexistentTime NonexistentTimePolicy = iota
+
+	// ShiftBackwardNonexistentTime moves the time backward by the length of the
+	// gap, for example 2:30 AM becomes 1:30 AM.
+	ShiftBackwardNonexistentTime
+
+	// SkipNonexistentTime skips the step entirely and proceeds to the next one.
+	SkipNonexistentTime
+)
+
+// AmbiguousTimePolicy controls what the `WallClockStepMode` does when a step
+// lands on a local time which happens twice, for example 1:30 AM on the day
+// clocks "fall back" from 2 AM to 1 AM.
+type AmbiguousTimePolicy int
+
+const (
+	// EarlierAmbiguousTime picks the first occurrence of the time (the offset
+	// before the transition). This is the default policy.
+	EarlierAmbiguousTime AmbiguousTimePolicy = iota
+
+	// LaterAmbiguousTime picks the second occurrence of the time (the offset
+	// after the transition).
+	LaterAmbiguousTime
+)
+
 // TimeStepper is a structure to hold keep track of the position we are in the datetime range which we are stepping through.
 type TimeStepper struct {
-	tz         *time.Location
-	curr       time.Time
-	start      time.Time
-	end        time.Time
-	yearStep   int
-	monthStep  int
-	dayStep    int
-	hourStep   int
-	minuteStep int
-	secondStep int
-}
-
-// NewTimeStepper is a constructor of the `TimeStepper` struct.
-func NewTimeStepper(start time.Time, end time.Time, yearStep int, monthStep int, dayStep int, hourStep int, minuteStep int, secondStep int) *TimeStepper {
-	return &TimeStepper{
+	tz                    *time.Location
+	curr                  time.Time
+	start                 time.Time
+	end                   time.Time
+	yearStep              int
+	monthStep             int
+	dayStep               int
+	hourStep              int
+	minuteStep            int
+	secondStep            int
+	mode                  StepMode
+	nonexistentTimePolicy NonexistentTimePolicy
+	ambiguousTimePolicy   AmbiguousTimePolicy
+
+	// reverse is true when the steps move backwards in time, in which case
+	// the stepper iterates from the latest `start` down to the earliest `end`.
+	reverse bool
+
+	// wall holds the local date and time of day (stored in UTC) the stepper is
+	// on when using the `WallClockStepMode`. We need to track it separately
+	// from `curr` because a nonexistent time may have been shifted or skipped.
+	wall time.Time
+}
+
+// TimeStepperOption is a function which configures optional behaviour of the
+// `TimeStepper` when passed into the `NewTimeStepper` constructor.
+type TimeStepperOption func(*TimeStepper)
+
+// WithStepMode returns an option which sets how the stepper applies its steps.
+func WithStepMode(mode StepMode) TimeStepperOption {
+	return func(ts *TimeStepper) {
+		ts.mode = mode
+	}
+}
+
+// WithNonexistentTimePolicy returns an option which sets what the
+// `WallClockStepMode` does when a step lands on a nonexistent local time.
+func WithNonexistentTimePolicy(policy NonexistentTimePolicy) TimeStepperOption {
+	return func(ts *TimeStepper) {
+		ts.nonexistentTimePolicy = policy
+	}
+}
+
+// WithAmbiguousTimePolicy returns an option which sets what the
+// `WallClockStepMode` does when a step lands on an ambiguous local time.
+func WithAmbiguousTimePolicy(policy AmbiguousTimePolicy) TimeStepperOption {
+	return func(ts *TimeStepper) {
+		ts.ambiguousTimePolicy = policy
+	}
+}
+
+// NewTimeStepper is a constructor of the `TimeStepper` struct. By default the
+// stepper uses the `AbsoluteStepMode`, pass in `WithStepMode(WallClockStepMode)`
+// to keep the local time of day across daylight saving transitions.
+//
+// The direction of iteration is detected from the sign of the steps. If the
+// steps move backwards in time (ex: `dayStep` of -1) then the stepper iterates
+// from `start` down to and including `end`, so `start` should be after `end`.
+func NewTimeStepper(start time.Time, end time.Time, yearStep int, monthStep int, dayStep int, hourStep int, minuteStep int, secondStep int, opts ...TimeStepperOption) *TimeStepper {
+	ts := &TimeStepper{
 		tz:         start.Location(),
 		curr:       start,
 		start:      start,
@@ -32,6 +128,19 @@ func NewTimeStepper(start time.Time, end time.Time, yearStep int, monthStep int,
 		minuteStep: minuteStep,
 		secondStep: secondStep,
 	}
+	for _, opt := range opts {
+		opt(ts)
+	}
+
+	// Developers Note:
+	// Steps can mix signs (ex: +1 month and -1 day) so we detect the direction
+	// by applying a single step to our start and seeing where we land.
+	ts.reverse = start.AddDate(yearStep, monthStep, dayStep).Add(ts.duration()).Before(start)
+
+	if ts.mode == WallClockStepMode {
+		ts.wall = wallClockOf(start)
+	}
+	return ts
 }
 
 // Next makes one time step over and returns true or false depending if the stepper has stepped over the end datetime.
@@ -48,44 +157,424 @@ func (ts *TimeStepper) Next() bool {
 	// converting the current time into a UTC timezone value, performing our step
 	// and then converting back to the specific local timezone - therfore fixing
 	// our issue.
-	currUTC := ts.curr.UTC()
-	dur := time.Hour*time.Duration(ts.hourStep) + time.Minute*time.Duration(ts.minuteStep) + time.Second*time.Duration(ts.secondStep)
-	currUTC = currUTC.AddDate(ts.yearStep, ts.monthStep, ts.dayStep).Add(dur)
-	ts.curr = currUTC.In(ts.tz)
+	if ts.mode == WallClockStepMode {
+		ts.nextWallClock()
+	} else {
+		currUTC := ts.curr.UTC()
+		currUTC = currUTC.AddDate(ts.yearStep, ts.monthStep, ts.dayStep).Add(ts.duration())
+		ts.curr = currUTC.In(ts.tz)
+	}
 
 	// Returns true or false depending if the stepper has stepped over the end datetime.
-	return ts.curr.After(ts.end) == false
+	return ts.Done() == false
 }
 
-// Done checks to see if the stepper has stepped over the end datetime and will return true or false according.
+// duration returns the hour, minute and second steps as a single duration.
+func (ts *TimeStepper) duration() time.Duration {
+	return time.Hour*time.Duration(ts.hourStep) + time.Minute*time.Duration(ts.minuteStep) + time.Second*time.Duration(ts.secondStep)
+}
+
+// nextWallClock makes one time step over the local date and time of day and
+// then resolves it back into an instant using the stepper's policies.
+func (ts *TimeStepper) nextWallClock() {
+	for {
+		// Developers Note:
+		// The `wall` value is stored in UTC which has no daylight saving, so
+		// our arithmetic here is pure calendar arithmetic on the local fields.
+		prev := ts.wall
+		ts.wall = ts.wall.AddDate(ts.yearStep, ts.monthStep, ts.dayStep).Add(ts.duration())
+
+		dt, ok := resolveWallClock(ts.wall, ts.tz, ts.nonexistentTimePolicy, ts.ambiguousTimePolicy)
+		ts.curr = dt
+
+		// If the step landed on a nonexistent time which we must skip then
+		// keep stepping, unless we stepped past the end (or we have no step at
+		// all) in which case we stop here so the stepper reports it is done.
+		if ok || ts.Done() || ts.wall.Equal(prev) {
+			return
+		}
+	}
+}
+
+// wallClockOf returns the local date and time of day of the inputted
+// date/time stored in the UTC location.
+func wallClockOf(dt time.Time) time.Time {
+	return time.Date(dt.Year(), dt.Month(), dt.Day(), dt.Hour(), dt.Minute(), dt.Second(), dt.Nanosecond(), time.UTC)
+}
+
+// resolveScheduledWallClock converts the local time (stored in UTC) of a
+// scheduled job into an instant like the traditional cron does. Local times
+// skipped by the clocks moving forward resolve to the moment the clocks
+// changed and repeated local times resolve to the first instant.
+func resolveScheduledWallClock(wall time.Time, loc *time.Location) time.Time {
+	dt, ok := resolveWallClock(wall, loc, SkipNonexistentTime, EarlierAmbiguousTime)
+	if ok {
+		return dt
+	}
+
+	// Developers Note:
+	// The skipped time is inside the gap so the change happened between our
+	// local time read with the offset after the change (which is too early)
+	// and the time shifted forward. We binary search for the second it did.
+	_, afterOffset := wall.Add(24 * time.Hour).In(loc).Zone()
+	lo, hi := wall.Add(-time.Duration(afterOffset)*time.Second).Unix(), dt.Unix()
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if _, offset := time.Unix(mid, 0).In(loc).Zone(); offset == afterOffset {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return time.Unix(hi, 0).In(loc)
+}
+
+// resolveWallClock converts the local date and time of day (stored in UTC)
+// into an instant in the location. Nonexistent and ambiguous local times are
+// handled according to the policies. The boolean is false only when the time
+// is nonexistent and the policy is to skip it, in which case the returned
+// value is the time shifted forward so callers can still compare it.
+func resolveWallClock(wall time.Time, loc *time.Location, nonexistent NonexistentTimePolicy, ambiguous AmbiguousTimePolicy) (time.Time, bool) {
+	// Developers Note:
+	// We look up the UTC offset a day before and a day after our time, which
+	// are the offsets in use on either side of any transition. Converting our
+	// local time with each offset gives us at most two candidates which we
+	// then verify actually display as our local time.
+	_, beforeOffset := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, afterOffset := wall.Add(24 * time.Hour).In(loc).Zone()
+	before := wall.Add(-time.Duration(beforeOffset) * time.Second).In(loc)
+	after := wall.Add(-time.Duration(afterOffset) * time.Second).In(loc)
+	beforeOK := wallClockOf(before).Equal(wall)
+	afterOK := wallClockOf(after).Equal(wall)
+
+	switch {
+	case beforeOK && afterOK && !before.Equal(after):
+		if ambiguous == LaterAmbiguousTime {
+			return laterTime(before, after), true
+		}
+		return earlierTime(before, after), true
+	case beforeOK:
+		return before, true
+	case afterOK:
+		return after, true
+	case beforeOffset == afterOffset:
+		// Unusual location rules which we could not resolve, so we fall back
+		// to the standard library behaviour.
+		return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc), true
+	}
+
+	// The local time does not exist. Converting with the offset before the
+	// transition shifts the time forward by the gap and converting with the
+	// offset after the transition shifts the time backward by the gap.
+	switch nonexistent {
+	case ShiftBackwardNonexistentTime:
+		return after, true
+	case SkipNonexistentTime:
+		return before, false
+	default:
+		return before, true
+	}
+}
+
+// Done checks to see if the stepper has stepped over the end datetime and will return true or false according. When iterating in reverse the stepper is done once it has stepped before the end datetime.
 func (ts *TimeStepper) Done() bool {
+	if ts.reverse {
+		return ts.curr.Before(ts.end)
+	}
 	return ts.curr.After(ts.end)
 }
 
+// Seek moves the stepper onto the inputted date/time so the next call to
+// `Next` will step from there. This is useful for resuming a long running job
+// from a checkpointed value which was previously returned by `Get`.
+func (ts *TimeStepper) Seek(dt time.Time) {
+	ts.curr = dt.In(ts.tz)
+	if ts.mode == WallClockStepMode {
+		ts.wall = wallClockOf(ts.curr)
+	}
+}
+
+// Reset moves the stepper back onto the start datetime.
+func (ts *TimeStepper) Reset() {
+	ts.Seek(ts.start)
+}
+
+// IsReverse returns true if the stepper is iterating backwards in time.
+func (ts *TimeStepper) IsReverse() bool {
+	return ts.reverse
+}
+
 // Get will return the value that that the stepper is currently on.
 func (ts *TimeStepper) Get() time.Time {
 	return ts.curr
 }
 
-// RangeFromTimeStepper function returns an array of datetime values from the starting date up to and including the finish date according to the step pattern specified in the parameter.
-func RangeFromTimeStepper(start time.Time, end time.Time, yearStep int, monthStep int, dayStep int, hourStep int, minuteStep int, secondStep int) []time.Time {
-	var results []time.Time
+// NewReverseTimeStepper is a constructor of the `TimeStepper` struct which
+// iterates backwards in time from `start` down to and including `end`. The
+// steps are given as positive values and are subtracted on every step, for
+// example a `dayStep` of 1 will go from Jan 10th to Jan 9th.
+func NewReverseTimeStepper(start time.Time, end time.Time, yearStep int, monthStep int, dayStep int, hourStep int, minuteStep int, secondStep int, opts ...TimeStepperOption) *TimeStepper {
+	return NewTimeStepper(start, end, -yearStep, -monthStep, -dayStep, -hourStep, -minuteStep, -secondStep, opts...)
+}
 
-	// Developers Note:
-	// We want to leverage our already unit tested code for the `range` functionality so we will use the `TimeStepper`
-	// to iterate through the datetime values and add them to an `results` array.
-	ts := NewTimeStepper(start, end, yearStep, monthStep, dayStep, hourStep, minuteStep, secondStep)
-	running := true
-	for running {
-		// Get the value we are on in the timestepper.
-		v := ts.Get()
+// newDirectionalTimeStepper is a constructor of the `TimeStepper` struct which
+// iterates forwards if `start` is before `end` or backwards if `start` is
+// after `end`. The steps are given as positive values.
+func newDirectionalTimeStepper(start time.Time, end time.Time, yearStep int, monthStep int, dayStep int, hourStep int, minuteStep int, secondStep int) *TimeStepper {
+	if start.After(end) {
+		return NewReverseTimeStepper(start, end, yearStep, monthStep, dayStep, hourStep, minuteStep, secondStep)
+	}
+	return NewTimeStepper(start, end, yearStep, monthStep, dayStep, hourStep, minuteStep, secondStep)
+}
 
-		results = append(results, v)
+// Len returns the total number of values the stepper produces from the start
+// datetime up to and including the end datetime. For steps of a fixed length
+// (days, hours, minutes and seconds) or of whole months and years the value is
+// calculated directly in constant time, otherwise the steps are counted.
+func (ts *TimeStepper) Len() int {
+	if ts.isPast(ts.start) {
+		return 0
+	}
+	if ts.isStill() {
+		return 1
+	}
+	if i, ok := ts.estimateIndex(ts.end); ok {
+		// Developers Note:
+		// Our estimate may be off by one or two because of daylight saving
+		// shifts or month lengths, so nudge it onto the last valid value.
+		if i < 0 {
+			i = 0
+		}
+		for i > 0 && ts.isPast(ts.nth(i)) {
+			i--
+		}
+		for !ts.isPast(ts.nth(i + 1)) {
+			i++
+		}
+		return i + 1
+	}
 
-		// Run our timestepper to get our next value.
-		ts.Next()
+	count := 0
+	for range ts.restarted().Seq() {
+		count++
+	}
+	return count
+}
 
-		running = ts.Done() == false
+// Nth returns the value at the zero based index without moving the stepper,
+// for example `Nth(0)` returns the start datetime. The boolean is false if the
+// index falls outside of the stepper's range. Combine this with `Seek` to jump
+// straight onto a page of a large range.
+func (ts *TimeStepper) Nth(i int) (time.Time, bool) {
+	if i < 0 {
+		return time.Time{}, false
+	}
+	if ts.isStill() {
+		return ts.start, i == 0 && !ts.isPast(ts.start)
+	}
+	if _, ok := ts.estimateIndex(ts.start); ok {
+		dt := ts.nth(i)
+		return dt, !ts.isPast(dt)
+	}
+
+	for dt := range ts.restarted().Seq() {
+		if i == 0 {
+			return dt, true
+		}
+		i--
+	}
+	return time.Time{}, false
+}
+
+// IndexOf returns the zero based index of the date/time in the stepper's
+// range. The boolean is false if the date/time is not one of the values the
+// stepper produces.
+func (ts *TimeStepper) IndexOf(dt time.Time) (int, bool) {
+	if ts.isPast(dt) {
+		return -1, false
+	}
+	if ts.isStill() {
+		if dt.Equal(ts.start) && !ts.isPast(ts.start) {
+			return 0, true
+		}
+		return -1, false
+	}
+	if i, ok := ts.estimateIndex(dt); ok {
+		for _, candidate := range []int{i - 1, i, i + 1} {
+			if candidate >= 0 && ts.nth(candidate).Equal(dt) {
+				return candidate, true
+			}
+		}
+		return -1, false
+	}
+
+	i := 0
+	for v := range ts.restarted().Seq() {
+		if v.Equal(dt) {
+			return i, true
+		}
+		i++
+	}
+	return -1, false
+}
+
+// isPast returns true if the date/time is beyond the end datetime in the
+// direction the stepper is iterating.
+func (ts *TimeStepper) isPast(dt time.Time) bool {
+	if ts.reverse {
+		return dt.Before(ts.end)
+	}
+	return dt.After(ts.end)
+}
+
+// isStill returns true if every step is zero, in which case the stepper never
+// moves off its start datetime and only produces that single value.
+func (ts *TimeStepper) isStill() bool {
+	return ts.yearStep == 0 && ts.monthStep == 0 && ts.dayStep == 0 && ts.duration() == 0
+}
+
+// restarted returns a copy of the stepper which is back on its start datetime.
+func (ts *TimeStepper) restarted() *TimeStepper {
+	cp := *ts
+	cp.Reset()
+	return &cp
+}
+
+// base returns the value our direct calculations step from, this is the start
+// datetime in UTC for the `AbsoluteStepMode` or the local date and time of
+// day for the `WallClockStepMode`. The boolean is false if the values can
+// only be found by stepping one by one.
+func (ts *TimeStepper) base() (time.Time, bool) {
+	switch {
+	case ts.mode == AbsoluteStepMode:
+		return ts.start.UTC(), true
+	case ts.nonexistentTimePolicy != SkipNonexistentTime:
+		return wallClockOf(ts.start), true
+	}
+	// Skipped values shift every following index so we cannot calculate them.
+	return time.Time{}, false
+}
+
+// fixedStep returns the length of one step if the stepper does not use year
+// or month steps. Days are always 24 hours long because we step in UTC (or
+// over the local time stored in UTC).
+func (ts *TimeStepper) fixedStep() (time.Duration, bool) {
+	if ts.yearStep != 0 || ts.monthStep != 0 {
+		return 0, false
+	}
+	step := 24*time.Hour*time.Duration(ts.dayStep) + ts.duration()
+	return step, step != 0
+}
+
+// calendarStep returns the number of months in one step if the stepper only
+// uses year and month steps.
+func (ts *TimeStepper) calendarStep() (int, bool) {
+	if ts.dayStep != 0 || ts.duration() != 0 {
+		return 0, false
+	}
+	months := 12*ts.yearStep + ts.monthStep
+	return months, months != 0
+}
+
+// estimateIndex returns the approximate index of the date/time calculated in
+// constant time. The boolean is false if the steps cannot be calculated
+// directly, in which case callers must step one by one.
+func (ts *TimeStepper) estimateIndex(dt time.Time) (int, bool) {
+	base, ok := ts.base()
+	if !ok {
+		return 0, false
+	}
+	target := dt.UTC()
+	if ts.mode == WallClockStepMode {
+		target = wallClockOf(dt.In(ts.tz))
+	}
+
+	if step, ok := ts.fixedStep(); ok {
+		return int(target.Sub(base) / step), true
+	}
+	if months, ok := ts.calendarStep(); ok {
+		elapsed := (target.Year()-base.Year())*12 + int(target.Month()) - int(base.Month())
+		return elapsed / months, true
+	}
+	return 0, false
+}
+
+// nth returns the value at the index calculated in constant time. Callers must
+// first check the steps can be calculated directly with `estimateIndex`.
+func (ts *TimeStepper) nth(i int) time.Time {
+	base, _ := ts.base()
+
+	var dt time.Time
+	if step, ok := ts.fixedStep(); ok {
+		dt = base.Add(time.Duration(i) * step)
+	} else {
+		months, _ := ts.calendarStep()
+
+		// Developers Note:
+		// The `Next` function adds the months one step at a time, so a day
+		// which does not exist in the month (ex: Jan 31st + 1 month) will
+		// overflow into the following month (ex: Mar 3rd) and the stepper then
+		// stays on that new day. Days up to the 28th never overflow, so we only
+		// have to step one by one until we land on one of those days, which
+		// happens within a few steps.
+		dt = base
+		for i > 0 && dt.Day() > 28 {
+			dt = dt.AddDate(0, months, 0)
+			i--
+		}
+		dt = dt.AddDate(0, months*i, 0)
+	}
+
+	if ts.mode == WallClockStepMode {
+		dt, _ = resolveWallClock(dt, ts.tz, ts.nonexistentTimePolicy, ts.ambiguousTimePolicy)
+		return dt
+	}
+	return dt.In(ts.tz)
+}
+
+// Seq returns an iterator which yields the value the stepper is currently on
+// and then every following step up to and including the end datetime. Please
+// note the iterator advances the stepper itself, so if you `break` out of the
+// loop the stepper will remain on the last yielded value.
+func (ts *TimeStepper) Seq() iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for running := ts.Done() == false; running; running = ts.Next() {
+			if !yield(ts.Get()) {
+				return
+			}
+		}
+	}
+}
+
+// StepsFromTimeStepper returns an iterator which lazily yields the datetime values from the starting date up to and including the finish date according to the step pattern specified in the parameter. This is the lazy version of `RangeFromTimeStepper` which should be used when the range is too large to hold in memory.
+func StepsFromTimeStepper(start time.Time, end time.Time, yearStep int, monthStep int, dayStep int, hourStep int, minuteStep int, secondStep int) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		ts := NewTimeStepper(start, end, yearStep, monthStep, dayStep, hourStep, minuteStep, secondStep)
+		ts.Seq()(yield)
+	}
+}
+
+// Steps returns an iterator which lazily yields the datetime values from the starting date up to and including the finish date in fixed duration steps. A negative step will iterate backwards from the starting date down to the finish date. A zero step only yields the starting date.
+func Steps(start time.Time, end time.Time, step time.Duration) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for dt := start; (step >= 0 && !dt.After(end)) || (step < 0 && !dt.Before(end)); dt = dt.Add(step) {
+			if !yield(dt) || step == 0 {
+				return
+			}
+		}
+	}
+}
+
+// RangeFromTimeStepper function returns an array of datetime values from the starting date up to and including the finish date according to the step pattern specified in the parameter. If negative steps are given then the values are returned in descending order from the starting date down to and including the finish date.
+func RangeFromTimeStepper(start time.Time, end time.Time, yearStep int, monthStep int, dayStep int, hourStep int, minuteStep int, secondStep int) []time.Time {
+	ts := NewTimeStepper(start, end, yearStep, monthStep, dayStep, hourStep, minuteStep, secondStep)
+
+	// Developers Note:
+	// Only preallocate when the length can be calculated directly, otherwise
+	// we would step through the entire range twice.
+	size := 0
+	if _, ok := ts.estimateIndex(start); ok {
+		size = ts.Len()
 	}
-	return results
+	return slices.AppendSeq(make([]time.Time, 0, size), ts.Seq())
 }
@@ -0,0 +1,201 @@
+package timekit
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// timeStepperVersion is the version of the binary encoding of the
+// `TimeStepper` which is written as the first byte of the encoded data. The
+// second version added the fixed zone offset, the first version can still be
+// decoded.
+const timeStepperVersion byte = 2
+
+// timeStepperState is the serializable representation of the `TimeStepper`.
+type timeStepperState struct {
+	Start                 time.Time             `json:"start"`
+	End                   time.Time             `json:"end"`
+	Current               time.Time             `json:"current"`
+	Location              string                `json:"location"`
+	FixedZone             bool                  `json:"fixed_zone,omitempty"`
+	Offset                int                   `json:"offset,omitempty"`
+	YearStep              int                   `json:"year_step"`
+	MonthStep             int                   `json:"month_step"`
+	DayStep               int                   `json:"day_step"`
+	HourStep              int                   `json:"hour_step"`
+	MinuteStep            int                   `json:"minute_step"`
+	SecondStep            int                   `json:"second_step"`
+	Mode                  StepMode              `json:"mode"`
+	NonexistentTimePolicy NonexistentTimePolicy `json:"nonexistent_time_policy"`
+	AmbiguousTimePolicy   AmbiguousTimePolicy   `json:"ambiguous_time_policy"`
+	Wall                  time.Time             `json:"wall"`
+}
+
+// state returns the serializable representation of the stepper.
+func (ts *TimeStepper) state() *timeStepperState {
+	// Developers Note:
+	// Fixed zones (ex: parsed from an RFC 3339 offset) have no name or a name
+	// which is not in the IANA database, so we save their offset instead.
+	name := ts.tz.String()
+	fixed := name == ""
+	if !fixed {
+		_, err := time.LoadLocation(name)
+		fixed = err != nil
+	}
+	offset := 0
+	if fixed {
+		_, offset = ts.start.In(ts.tz).Zone()
+	}
+
+	return &timeStepperState{
+		Start:                 ts.start,
+		End:                   ts.end,
+		Current:               ts.curr,
+		Location:              name,
+		FixedZone:             fixed,
+		Offset:                offset,
+		YearStep:              ts.yearStep,
+		MonthStep:             ts.monthStep,
+		DayStep:               ts.dayStep,
+		HourStep:              ts.hourStep,
+		MinuteStep:            ts.minuteStep,
+		SecondStep:            ts.secondStep,
+		Mode:                  ts.mode,
+		NonexistentTimePolicy: ts.nonexistentTimePolicy,
+		AmbiguousTimePolicy:   ts.ambiguousTimePolicy,
+		Wall:                  ts.wall,
+	}
+}
+
+// setState replaces the stepper with the serialized representation.
+func (ts *TimeStepper) setState(st *timeStepperState) error {
+	// Developers Note:
+	// Encoded date/times only keep their UTC offset and not their location, so
+	// we load the location by name to get the correct daylight saving rules.
+	loc := time.FixedZone(st.Location, st.Offset)
+	if !st.FixedZone {
+		var err error
+		loc, err = time.LoadLocation(st.Location)
+		if err != nil {
+			return fmt.Errorf("timekit: failed loading time stepper location %q: %w", st.Location, err)
+		}
+	}
+
+	restored := NewTimeStepper(st.Start.In(loc), st.End.In(loc), st.YearStep, st.MonthStep, st.DayStep, st.HourStep, st.MinuteStep, st.SecondStep,
+		WithStepMode(st.Mode),
+		WithNonexistentTimePolicy(st.NonexistentTimePolicy),
+		WithAmbiguousTimePolicy(st.AmbiguousTimePolicy),
+	)
+	restored.curr = st.Current.In(loc)
+	if restored.mode == WallClockStepMode {
+		restored.wall = st.Wall.UTC()
+	}
+	*ts = *restored
+	return nil
+}
+
+// MarshalJSON implements the `json.Marshaler` interface so the full state of
+// the stepper (including the position it is currently on) can be saved.
+func (ts *TimeStepper) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ts.state())
+}
+
+// UnmarshalJSON implements the `json.Unmarshaler` interface so a previously
+// saved stepper can be resumed from the exact position it was on.
+func (ts *TimeStepper) UnmarshalJSON(data []byte) error {
+	st := &timeStepperState{}
+	if err := json.Unmarshal(data, st); err != nil {
+		return err
+	}
+	return ts.setState(st)
+}
+
+// MarshalBinary implements the `encoding.BinaryMarshaler` interface.
+func (ts *TimeStepper) MarshalBinary() ([]byte, error) {
+	st := ts.state()
+	data := []byte{timeStepperVersion}
+	for _, dt := range []time.Time{st.Start, st.End, st.Current, st.Wall} {
+		b, err := dt.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		data = binary.AppendUvarint(data, uint64(len(b)))
+		data = append(data, b...)
+	}
+	data = binary.AppendUvarint(data, uint64(len(st.Location)))
+	data = append(data, st.Location...)
+	fixed := 0
+	if st.FixedZone {
+		fixed = 1
+	}
+	for _, v := range []int{st.YearStep, st.MonthStep, st.DayStep, st.HourStep, st.MinuteStep, st.SecondStep, int(st.Mode), int(st.NonexistentTimePolicy), int(st.AmbiguousTimePolicy), fixed, st.Offset} {
+		data = binary.AppendVarint(data, int64(v))
+	}
+	return data, nil
+}
+
+// errInvalidTimeStepperData is returned when the binary data of the stepper
+// is truncated or corrupted.
+var errInvalidTimeStepperData = errors.New("timekit: invalid time stepper binary data")
+
+// UnmarshalBinary implements the `encoding.BinaryUnmarshaler` interface.
+func (ts *TimeStepper) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] < 1 || data[0] > timeStepperVersion {
+		return errInvalidTimeStepperData
+	}
+	version := data[0]
+	data = data[1:]
+
+	// readBytes reads a length prefixed slice of bytes from our data.
+	readBytes := func() ([]byte, error) {
+		n, size := binary.Uvarint(data)
+		if size <= 0 || uint64(len(data)-size) < n {
+			return nil, errInvalidTimeStepperData
+		}
+		b := data[size : size+int(n)]
+		data = data[size+int(n):]
+		return b, nil
+	}
+
+	st := &timeStepperState{}
+	for _, dt := range []*time.Time{&st.Start, &st.End, &st.Current, &st.Wall} {
+		b, err := readBytes()
+		if err != nil {
+			return err
+		}
+		if err := dt.UnmarshalBinary(b); err != nil {
+			return err
+		}
+	}
+	b, err := readBytes()
+	if err != nil {
+		return err
+	}
+	st.Location = string(b)
+
+	values := make([]int64, 9, 11)
+	if version >= 2 {
+		values = values[:11]
+	}
+	for i := range values {
+		v, size := binary.Varint(data)
+		if size <= 0 {
+			return errInvalidTimeStepperData
+		}
+		values[i] = v
+		data = data[size:]
+	}
+	st.YearStep, st.MonthStep, st.DayStep = int(values[0]), int(values[1]), int(values[2])
+	st.HourStep, st.MinuteStep, st.SecondStep = int(values[3]), int(values[4]), int(values[5])
+	st.Mode = StepMode(values[6])
+	st.NonexistentTimePolicy = NonexistentTimePolicy(values[7])
+	st.AmbiguousTimePolicy = AmbiguousTimePolicy(values[8])
+	if version >= 2 {
+		st.FixedZone, st.Offset = values[9] == 1, int(values[10])
+	}
+
+	return ts.setState(st)
+}
@@ -0,0 +1,126 @@
+package timekit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeStepperJSON(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Toronto")
+	start := time.Date(2023, 3, 10, 9, 0, 0, 0, loc) // Friday Mar 10th - 9 AM EST
+	end := time.Date(2023, 3, 20, 9, 0, 0, 0, loc)   // Monday Mar 20th - 9 AM EDT
+	ts := NewTimeStepper(start, end, 0, 0, 1, 0, 0, 0, WithStepMode(WallClockStepMode))
+	ts.Next()
+	ts.Next()
+
+	// Checkpoint our position and then resume in a brand new stepper.
+	data, err := json.Marshal(ts)
+	if err != nil {
+		t.Fatalf("Failed marshalling stepper: %v", err)
+	}
+	resumed := &TimeStepper{}
+	if err := json.Unmarshal(data, resumed); err != nil {
+		t.Fatalf("Failed unmarshalling stepper: %v", err)
+	}
+
+	if resumed.Get().Location().String() != "America/Toronto" {
+		t.Errorf("Incorrect location, got %s but was expecting %s", resumed.Get().Location(), loc)
+	}
+	for ts.Next() {
+		resumed.Next()
+		if !resumed.Get().Equal(ts.Get()) {
+			t.Fatalf("Incorrect date, got %s but was expecting %s", resumed.Get(), ts.Get())
+		}
+	}
+	if resumed.Next() {
+		t.Errorf("Incorrect value, resumed stepper should be done")
+	}
+}
+
+func TestTimeStepperBinary(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/London")
+	start := time.Date(2023, 1, 31, 6, 0, 0, 0, loc) // Jan 31st 2023
+	end := time.Date(2022, 1, 31, 6, 0, 0, 0, loc)   // Jan 31st 2022
+	ts := NewReverseTimeStepper(start, end, 0, 1, 0, 0, 0, 0)
+	ts.Next()
+
+	data, err := ts.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed marshalling stepper: %v", err)
+	}
+	resumed := &TimeStepper{}
+	if err := resumed.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Failed unmarshalling stepper: %v", err)
+	}
+	if !resumed.IsReverse() {
+		t.Errorf("Incorrect value, got %v but was expecting %v", resumed.IsReverse(), true)
+	}
+	for ts.Next() {
+		resumed.Next()
+		if !resumed.Get().Equal(ts.Get()) {
+			t.Fatalf("Incorrect date, got %s but was expecting %s", resumed.Get(), ts.Get())
+		}
+	}
+
+	// Truncated data must be rejected.
+	if err := resumed.UnmarshalBinary(data[:len(data)/2]); err == nil {
+		t.Errorf("Incorrect result, got nil but was expecting an error")
+	}
+}
+
+func TestTimeStepperFixedZone(t *testing.T) {
+	parsed, err := time.Parse(time.RFC3339, "2023-03-10T09:00:00+02:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	named := time.Date(2023, 3, 10, 9, 0, 0, 0, time.FixedZone("Office", -5*60*60))
+
+	for _, start := range []time.Time{parsed, named} {
+		_, expectedOffset := start.Zone()
+		ts := NewTimeStepper(start, start.AddDate(0, 0, 5), 0, 0, 1, 0, 0, 0)
+		ts.Next()
+
+		////
+		//// Case 1: JSON keeps the offset.
+		////
+
+		data, err := json.Marshal(ts)
+		if err != nil {
+			t.Fatalf("Failed marshalling stepper: %v", err)
+		}
+		fromJSON := &TimeStepper{}
+		if err := json.Unmarshal(data, fromJSON); err != nil {
+			t.Fatalf("Failed unmarshalling stepper: %v", err)
+		}
+
+		////
+		//// Case 2: Binary keeps the offset.
+		////
+
+		data, err = ts.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Failed marshalling stepper: %v", err)
+		}
+		fromBinary := &TimeStepper{}
+		if err := fromBinary.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Failed unmarshalling stepper: %v", err)
+		}
+
+		for _, resumed := range []*TimeStepper{fromJSON, fromBinary} {
+			if _, offset := resumed.Get().Zone(); offset != expectedOffset {
+				t.Errorf("Incorrect offset, got %d but was expecting %d", offset, expectedOffset)
+			}
+			if resumed.Get().Location().String() != start.Location().String() {
+				t.Errorf("Incorrect location, got %q but was expecting %q", resumed.Get().Location(), start.Location())
+			}
+		}
+		for ts.Next() {
+			fromJSON.Next()
+			fromBinary.Next()
+			if !ts.Get().Equal(fromJSON.Get()) || !ts.Get().Equal(fromBinary.Get()) {
+				t.Fatalf("Incorrect date, got %s and %s but was expecting %s", fromJSON.Get(), fromBinary.Get(), ts.Get())
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package timekit
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 )
@@ -128,3 +129,296 @@ func TestRangeFromTimeStepper(t *testing.T) {
 		t.Errorf("Incorrect date ranges, got %s but was expecting %s", actual, expected)
 	}
 }
+
+func TestTimeStepperAbsoluteStepModeDrifts(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Toronto")
+	start := time.Date(2023, 3, 11, 9, 0, 0, 0, loc) // Saturday Mar 11th - 9 AM EST
+	end := time.Date(2023, 3, 13, 9, 0, 0, 0, loc)   // Monday Mar 13th - 9 AM EDT
+	actual := RangeFromTimeStepper(start, end, 0, 0, 1, 0, 0, 0)
+	expected := []time.Time{
+		time.Date(2023, 3, 11, 9, 0, 0, 0, loc),
+		time.Date(2023, 3, 12, 10, 0, 0, 0, loc), // Drifted an hour after springing forward.
+	}
+	if !timeEqual(expected, actual) {
+		t.Errorf("Incorrect dates, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestTimeStepperWallClockStepMode(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Toronto")
+	start := time.Date(2023, 3, 11, 9, 0, 0, 0, loc) // Saturday Mar 11th - 9 AM EST
+	end := time.Date(2023, 11, 6, 9, 0, 0, 0, loc)   // Monday Nov 6th - 9 AM EST
+	ts := NewTimeStepper(start, end, 0, 0, 1, 0, 0, 0, WithStepMode(WallClockStepMode))
+
+	count := 1
+	for ts.Next() {
+		dt := ts.Get()
+		if dt.Hour() != 9 || dt.Minute() != 0 {
+			t.Fatalf("Incorrect time of day, got %s but was expecting 9 AM", dt)
+		}
+		count++
+	}
+	if expected := 241; count != expected {
+		t.Errorf("Incorrect number of steps, got %d but was expecting %d", count, expected)
+	}
+}
+
+func TestTimeStepperWallClockNonexistentTimePolicy(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Toronto")
+	start := time.Date(2023, 3, 11, 2, 30, 0, 0, loc) // Saturday Mar 11th - 2:30 AM EST
+	end := time.Date(2023, 3, 13, 2, 30, 0, 0, loc)   // Monday Mar 13th - 2:30 AM EDT
+
+	tests := []struct {
+		policy   NonexistentTimePolicy
+		expected []time.Time
+	}{
+		{ShiftForwardNonexistentTime, []time.Time{
+			start,
+			time.Date(2023, 3, 12, 7, 30, 0, 0, time.UTC).In(loc), // 3:30 AM EDT
+			end,
+		}},
+		{ShiftBackwardNonexistentTime, []time.Time{
+			start,
+			time.Date(2023, 3, 12, 6, 30, 0, 0, time.UTC).In(loc), // 1:30 AM EST
+			end,
+		}},
+		{SkipNonexistentTime, []time.Time{
+			start,
+			end,
+		}},
+	}
+	for _, tc := range tests {
+		var actual []time.Time
+		ts := NewTimeStepper(start, end, 0, 0, 1, 0, 0, 0, WithStepMode(WallClockStepMode), WithNonexistentTimePolicy(tc.policy))
+		for running := true; running; running = ts.Next() {
+			actual = append(actual, ts.Get())
+		}
+		if !timeEqual(tc.expected, actual) {
+			t.Errorf("Incorrect dates for policy %d, got %s but was expecting %s", tc.policy, actual, tc.expected)
+		}
+	}
+}
+
+func TestTimeStepperWallClockAmbiguousTimePolicy(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Toronto")
+	start := time.Date(2023, 11, 4, 1, 30, 0, 0, loc) // Saturday Nov 4th - 1:30 AM EDT
+	end := time.Date(2023, 11, 6, 1, 30, 0, 0, loc)   // Monday Nov 6th - 1:30 AM EST
+
+	tests := []struct {
+		policy   AmbiguousTimePolicy
+		expected time.Time
+	}{
+		{EarlierAmbiguousTime, time.Date(2023, 11, 5, 5, 30, 0, 0, time.UTC).In(loc)}, // 1:30 AM EDT
+		{LaterAmbiguousTime, time.Date(2023, 11, 5, 6, 30, 0, 0, time.UTC).In(loc)},   // 1:30 AM EST
+	}
+	for _, tc := range tests {
+		ts := NewTimeStepper(start, end, 0, 0, 1, 0, 0, 0, WithStepMode(WallClockStepMode), WithAmbiguousTimePolicy(tc.policy))
+		ts.Next()
+		if actual := ts.Get(); !actual.Equal(tc.expected) {
+			t.Errorf("Incorrect date for policy %d, got %s but was expecting %s", tc.policy, actual, tc.expected)
+		}
+	}
+}
+
+func TestReverseTimeStepper(t *testing.T) {
+	loc := time.UTC                                  // closure can be used if necessary
+	start := time.Date(2022, 1, 10, 1, 0, 0, 0, loc) // Jan 10th 2022
+	end := time.Date(2022, 1, 7, 1, 0, 0, 0, loc)    // Jan 7th 2022
+	ts := NewReverseTimeStepper(start, end, 0, 0, 1, 0, 0, 0)
+	if ts.IsReverse() == false {
+		t.Errorf("Incorrect value, got %v but was expecting %v", ts.IsReverse(), true)
+	}
+
+	var actual []time.Time
+	for running := true; running; running = ts.Next() {
+		actual = append(actual, ts.Get())
+	}
+	expected := []time.Time{
+		time.Date(2022, 1, 10, 1, 0, 0, 0, loc), // Jan 10th 2022
+		time.Date(2022, 1, 9, 1, 0, 0, 0, loc),  // Jan 9th 2022
+		time.Date(2022, 1, 8, 1, 0, 0, 0, loc),  // Jan 8th 2022
+		time.Date(2022, 1, 7, 1, 0, 0, 0, loc),  // Jan 7th 2022
+	}
+	if reflect.DeepEqual(actual, expected) == false {
+		t.Errorf("Incorrect date ranges, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestRangeFromTimeStepperNegativeSteps(t *testing.T) {
+	loc := time.UTC                                  // closure can be used if necessary
+	start := time.Date(2022, 1, 10, 6, 0, 0, 0, loc) // Jan 10th 2022 - 6 AM
+	end := time.Date(2022, 1, 10, 0, 0, 0, 0, loc)   // Jan 10th 2022 - 12 AM
+	actual := RangeFromTimeStepper(start, end, 0, 0, 0, -2, 0, 0)
+	expected := []time.Time{
+		time.Date(2022, 1, 10, 6, 0, 0, 0, loc),
+		time.Date(2022, 1, 10, 4, 0, 0, 0, loc),
+		time.Date(2022, 1, 10, 2, 0, 0, 0, loc),
+		time.Date(2022, 1, 10, 0, 0, 0, 0, loc),
+	}
+	if reflect.DeepEqual(actual, expected) == false {
+		t.Errorf("Incorrect date ranges, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestStepsFromTimeStepper(t *testing.T) {
+	loc := time.UTC                                 // closure can be used if necessary
+	start := time.Date(2022, 1, 7, 1, 0, 0, 0, loc) // Jan 7th 2022
+	end := time.Date(2027, 1, 7, 1, 0, 0, 0, loc)   // Jan 7th 2027
+
+	// Five years at one second steps is too large to hold in memory, but we
+	// can lazily iterate and `break` whenever we want.
+	var actual []time.Time
+	for dt := range StepsFromTimeStepper(start, end, 0, 0, 0, 0, 0, 1) {
+		if len(actual) == 3 {
+			break
+		}
+		actual = append(actual, dt)
+	}
+	expected := []time.Time{
+		time.Date(2022, 1, 7, 1, 0, 0, 0, loc),
+		time.Date(2022, 1, 7, 1, 0, 1, 0, loc),
+		time.Date(2022, 1, 7, 1, 0, 2, 0, loc),
+	}
+	if reflect.DeepEqual(actual, expected) == false {
+		t.Errorf("Incorrect date ranges, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestSteps(t *testing.T) {
+	loc := time.UTC                                 // closure can be used if necessary
+	start := time.Date(2022, 1, 7, 1, 0, 0, 0, loc) // Jan 7th 2022 - 1:00 AM
+	end := time.Date(2022, 1, 7, 1, 40, 0, 0, loc)  // Jan 7th 2022 - 1:40 AM
+	actual := slices.Collect(Steps(start, end, 15*time.Minute))
+	expected := []time.Time{
+		time.Date(2022, 1, 7, 1, 0, 0, 0, loc),
+		time.Date(2022, 1, 7, 1, 15, 0, 0, loc),
+		time.Date(2022, 1, 7, 1, 30, 0, 0, loc),
+	}
+	if reflect.DeepEqual(actual, expected) == false {
+		t.Errorf("Incorrect date ranges, got %s but was expecting %s", actual, expected)
+	}
+
+	// Negative steps iterate backwards.
+	actual = slices.Collect(Steps(end, start, -20*time.Minute))
+	expected = []time.Time{
+		time.Date(2022, 1, 7, 1, 40, 0, 0, loc),
+		time.Date(2022, 1, 7, 1, 20, 0, 0, loc),
+		time.Date(2022, 1, 7, 1, 0, 0, 0, loc),
+	}
+	if reflect.DeepEqual(actual, expected) == false {
+		t.Errorf("Incorrect date ranges, got %s but was expecting %s", actual, expected)
+	}
+}
+
+func TestTimeStepperSeekAndReset(t *testing.T) {
+	loc := time.UTC                                 // closure can be used if necessary
+	start := time.Date(2022, 1, 7, 1, 0, 0, 0, loc) // Jan 7th 2022
+	end := time.Date(2022, 1, 10, 1, 0, 0, 0, loc)  // Jan 10th 2022
+	ts := NewTimeStepper(start, end, 0, 0, 1, 0, 0, 0)
+
+	ts.Seek(time.Date(2022, 1, 9, 1, 0, 0, 0, loc))
+	ts.Next()
+	expected := time.Date(2022, 1, 10, 1, 0, 0, 0, loc) // Jan 10th 2022
+	if actual := ts.Get(); expected != actual {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+
+	ts.Reset()
+	if actual := ts.Get(); start != actual {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, start)
+	}
+}
+
+func TestTimeStepperRandomAccess(t *testing.T) {
+	toronto, _ := time.LoadLocation("America/Toronto")
+	tests := []struct {
+		name string
+		ts   *TimeStepper
+	}{
+		{"minutes", NewTimeStepper(time.Date(2023, 3, 11, 0, 0, 0, 0, toronto), time.Date(2023, 3, 13, 0, 0, 0, 0, toronto), 0, 0, 0, 0, 7, 0)},
+		{"days and hours", NewTimeStepper(time.Date(2023, 1, 1, 0, 0, 0, 0, toronto), time.Date(2023, 12, 31, 0, 0, 0, 0, toronto), 0, 0, 1, 5, 0, 0)},
+		{"months from the 31st", NewTimeStepper(time.Date(2023, 1, 31, 9, 0, 0, 0, time.UTC), time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC), 0, 1, 0, 0, 0, 0)},
+		{"leap day years", NewTimeStepper(time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), time.Date(2034, 2, 28, 9, 0, 0, 0, time.UTC), 1, 0, 0, 0, 0, 0)},
+		{"reverse months", NewReverseTimeStepper(time.Date(2024, 5, 31, 9, 0, 0, 0, toronto), time.Date(2020, 1, 1, 0, 0, 0, 0, toronto), 0, 2, 0, 0, 0, 0)},
+		{"wall clock days", NewTimeStepper(time.Date(2023, 1, 1, 2, 30, 0, 0, toronto), time.Date(2024, 1, 1, 0, 0, 0, 0, toronto), 0, 0, 1, 0, 0, 0, WithStepMode(WallClockStepMode))},
+		{"wall clock skip", NewTimeStepper(time.Date(2023, 3, 1, 2, 30, 0, 0, toronto), time.Date(2023, 4, 1, 0, 0, 0, 0, toronto), 0, 0, 1, 0, 0, 0, WithStepMode(WallClockStepMode), WithNonexistentTimePolicy(SkipNonexistentTime))},
+		{"mixed", NewTimeStepper(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 0, 1, 1, 0, 0, 0)},
+	}
+	for _, tc := range tests {
+		// Our expected values come from simply stepping one by one.
+		var expected []time.Time
+		for running := true; running; running = tc.ts.Next() {
+			expected = append(expected, tc.ts.Get())
+		}
+		tc.ts.Reset()
+
+		if actual := tc.ts.Len(); actual != len(expected) {
+			t.Errorf("Incorrect length for %s, got %d but was expecting %d", tc.name, actual, len(expected))
+		}
+		for i, exp := range expected {
+			if actual, ok := tc.ts.Nth(i); !ok || !actual.Equal(exp) {
+				t.Errorf("Incorrect value for %s at index %d, got %s but was expecting %s", tc.name, i, actual, exp)
+				break
+			}
+			if actual, ok := tc.ts.IndexOf(exp); !ok || actual != i {
+				t.Errorf("Incorrect index for %s of %s, got %d but was expecting %d", tc.name, exp, actual, i)
+				break
+			}
+		}
+		if _, ok := tc.ts.Nth(len(expected)); ok {
+			t.Errorf("Incorrect value for %s, index %d should be out of range", tc.name, len(expected))
+		}
+		if _, ok := tc.ts.IndexOf(expected[0].Add(time.Second)); ok {
+			t.Errorf("Incorrect index for %s, %s should not be found", tc.name, expected[0].Add(time.Second))
+		}
+		if actual := tc.ts.Get(); !actual.Equal(expected[0]) {
+			t.Errorf("Incorrect value for %s, random access should not move the stepper but got %s", tc.name, actual)
+		}
+	}
+}
+
+func TestTimeStepperRandomAccessLargeRange(t *testing.T) {
+	loc := time.UTC                                 // closure can be used if necessary
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, loc) // Jan 1st 2020
+	end := time.Date(2025, 1, 1, 0, 0, 0, 0, loc)   // Jan 1st 2025
+	ts := NewTimeStepper(start, end, 0, 0, 0, 0, 0, 1)
+
+	// Five years at one second steps would take far too long to walk through,
+	// so this verifies we calculate the values directly.
+	expectedLen := int(end.Sub(start)/time.Second) + 1
+	if actual := ts.Len(); actual != expectedLen {
+		t.Errorf("Incorrect length, got %d but was expecting %d", actual, expectedLen)
+	}
+	expected := start.Add(100_000_000 * time.Second)
+	if actual, ok := ts.Nth(100_000_000); !ok || !actual.Equal(expected) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, expected)
+	}
+	if actual, ok := ts.IndexOf(expected); !ok || actual != 100_000_000 {
+		t.Errorf("Incorrect index, got %d but was expecting %d", actual, 100_000_000)
+	}
+}
+
+func TestTimeStepperRandomAccessZeroSteps(t *testing.T) {
+	loc := time.UTC                                 // closure can be used if necessary
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, loc) // Jan 1st 2020
+	end := time.Date(2020, 1, 5, 0, 0, 0, 0, loc)   // Jan 5th 2020
+	ts := NewTimeStepper(start, end, 0, 0, 0, 0, 0, 0)
+
+	// A stepper which never moves only has its start value, and these must
+	// not walk through the values since they would never end.
+	if actual := ts.Len(); actual != 1 {
+		t.Errorf("Incorrect length, got %d but was expecting %d", actual, 1)
+	}
+	if actual, ok := ts.Nth(0); !ok || !actual.Equal(start) {
+		t.Errorf("Incorrect date, got %s but was expecting %s", actual, start)
+	}
+	if _, ok := ts.Nth(1); ok {
+		t.Errorf("Incorrect result, got true but was expecting false")
+	}
+	if actual, ok := ts.IndexOf(start); !ok || actual != 0 {
+		t.Errorf("Incorrect index, got %d but was expecting %d", actual, 0)
+	}
+	if _, ok := ts.IndexOf(end); ok {
+		t.Errorf("Incorrect result, got true but was expecting false")
+	}
+}